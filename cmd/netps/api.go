@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"netps/internal/api"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const tokenEnv = "NETPS_API_TOKEN"
+
+func runServeAPI(args []string) int {
+	fs := newFlagSet("serve-api", "serve-api [flags]\n\nThe token is read from --token-file or $"+tokenEnv+", signals are refused without one")
+	backend := backendFlags(fs)
+	listen := fs.String("listen", "127.0.0.1:9732", "loopback address to serve on")
+	unixPath := fs.String("unix", "", "serve on this unix socket instead, created with mode 0600")
+	tokenFile := fs.String("token-file", "", "file holding the bearer token for the signal endpoint")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	token, err := readToken(*tokenFile)
+	if err != nil {
+		return failf("serve-api", "%v", err)
+	}
+
+	var listener net.Listener
+	addr := *listen
+	if *unixPath != "" {
+		addr = *unixPath
+		listener, err = listenUnix(*unixPath)
+	} else {
+		listener, err = listenLoopback(*listen)
+	}
+	if err != nil {
+		return failf("serve-api", "%v", err)
+	}
+
+	processService, socketService, err := newServices(backend)
+	if err != nil {
+		listener.Close()
+		return failf("serve-api", "%v", err)
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           api.NewServer(processService, socketService, api.Config{Token: token, AnyHost: *unixPath != ""}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "netps serve-api: no token, the signal endpoint is disabled\n")
+	}
+	return serveUntilSignal("serve-api", server, func() error { return server.Serve(listener) })
+}
+
+// Keeps the token out of the process list, where a flag would show it to every user
+func readToken(path string) (string, error) {
+	if path == "" {
+		return os.Getenv(tokenEnv), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+// Anything but a loopback address would publish process data to the network
+func listenLoopback(addr string) (net.Listener, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if host != "localhost" {
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			return nil, fmt.Errorf("refusing to listen on %s, use a loopback address or --unix", addr)
+		}
+	}
+	return net.Listen("tcp", addr)
+}
+
+// A leftover socket from a previous run is replaced, any other file is left alone
+func listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode().Type() != fs.ModeSocket {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	// a chmod after Listen would leave a window where anyone can connect
+	umask := syscall.Umask(0o177)
+	listener, err := net.Listen("unix", path)
+	syscall.Umask(umask)
+	return listener, err
+}
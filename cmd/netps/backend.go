@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"netps/internal/netlink"
+	"netps/internal/process"
+	"netps/internal/procfs"
+	procnet "netps/internal/procfs/net"
+	"netps/internal/procfs/root"
+	"netps/internal/socket"
+	"netps/internal/sysconf"
+	"os"
+	"path/filepath"
+)
+
+const (
+	SocketBackendNetlink = "netlink"
+	SocketBackendProcfs  = "procfs"
+)
+
+// procRootEnv sets the default of --proc-root, handy in a container image
+const procRootEnv = "NETPS_PROC_ROOT"
+
+// Where processes and sockets are read from, every subcommand takes the same flags
+type backendOptions struct {
+	socketBackend string
+	procRoot      string
+}
+
+func backendFlags(fs *flag.FlagSet) *backendOptions {
+	o := &backendOptions{}
+	procRoot := os.Getenv(procRootEnv)
+	if procRoot == "" {
+		procRoot = root.DefaultPath
+	}
+	fs.StringVar(&o.socketBackend, "socket-backend", SocketBackendNetlink, "how sockets are resolved: netlink (falls back to procfs when unavailable) or procfs")
+	fs.StringVar(&o.procRoot, "proc-root", procRoot, "where proc is mounted, e.g. the host's /proc bind-mounted at /host/proc in a container, defaults to $"+procRootEnv+". Sockets are then read from its net/ tables, netlink only answers for the local system")
+	return o
+}
+
+// A mistyped --proc-root would otherwise show an empty host rather than an error
+func (o *backendOptions) procFS() (root.FS, error) {
+	fsys := root.Dir(o.procRoot)
+	if _, err := fsys.ReadFile("uptime"); err != nil {
+		return nil, fmt.Errorf("%s does not look like a proc mount: %w", o.procRoot, err)
+	}
+	return fsys, nil
+}
+
+// netlink falls back to /proc/net on its own when a dump fails, here we pick procfs upfront
+// when no sock_diag socket can be opened at all, or when proc is read from elsewhere:
+// netlink answers for our own network namespace, not for the processes under procRoot
+func newSocketTable(backend string, procRoot string, fsys root.FS) (procnet.SocketTable, error) {
+	switch backend {
+	case SocketBackendNetlink:
+		if filepath.Clean(procRoot) != root.DefaultPath || !netlink.Available() {
+			return procnet.ProcTable{FS: fsys}, nil
+		}
+		return netlink.NewTable(fsys), nil
+	case SocketBackendProcfs:
+		return procnet.ProcTable{FS: fsys}, nil
+	default:
+		return nil, fmt.Errorf("unknown socket backend %q, expected %s or %s", backend, SocketBackendNetlink, SocketBackendProcfs)
+	}
+}
+
+func newProcfsClient(o *backendOptions) (*procfs.Client, error) {
+	fsys, err := o.procFS()
+	if err != nil {
+		return nil, err
+	}
+	table, err := newSocketTable(o.socketBackend, o.procRoot, fsys)
+	if err != nil {
+		return nil, err
+	}
+	return procfs.NewClient(fsys).WithSocketTable(table), nil
+}
+
+func newServices(o *backendOptions) (*process.Service, *socket.Service, error) {
+	procfsClient, err := newProcfsClient(o)
+	if err != nil {
+		return nil, nil, err
+	}
+	sysconfClient := sysconf.NewClient()
+	cfg := process.Config{
+		Process:   procfsClient,
+		Name:      procfsClient,
+		Detail:    procfsClient,
+		Clocktick: sysconfClient,
+		PageSize:  sysconfClient,
+		UpTime:    procfsClient,
+		Resource:  procfsClient,
+		User:      procfsClient,
+		Signal:    procfsClient,
+	}
+	return process.NewProcessService(cfg), socket.NewService(procfsClient), nil
+}
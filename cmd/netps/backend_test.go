@@ -0,0 +1,22 @@
+package main
+
+import (
+	procnet "netps/internal/procfs/net"
+	"netps/internal/procfs/root"
+	"testing"
+)
+
+func TestNewSocketTableProcRoot(t *testing.T) {
+	for _, procRoot := range []string{"/host/proc", "/tmp/capture/proc", "/proc/1/root/proc"} {
+		table, err := newSocketTable(SocketBackendNetlink, procRoot, root.Dir(procRoot))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := table.(procnet.ProcTable); !ok {
+			t.Errorf("--proc-root %s with netlink: got %T, want the procfs table", procRoot, table)
+		}
+	}
+	if _, err := newSocketTable("ebpf", root.DefaultPath, root.Default()); err == nil {
+		t.Error("unknown backend accepted")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// Non-interactive entry points, none of them needs a TTY
+type subcommand struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string) int
+}
+
+var subcommands = []subcommand{
+	{name: "list", usage: "list [flags]", summary: "print processes with sockets as a table, JSON, NDJSON or CSV", run: runList},
+	{name: "inspect", usage: "inspect [flags] <pid>", summary: "print everything known about a process as JSON or YAML", run: runInspect},
+	{name: "who", usage: "who [flags] <port>", summary: "show which processes hold a port, exits 3 when none does", run: runWho},
+	{name: "kill", usage: "kill --port <port> [flags]", summary: "signal the processes holding a port, optionally waiting and escalating to SIGKILL", run: runKill},
+	{name: "watch", usage: "watch [flags]", summary: "stream socket open and close events as text or NDJSON", run: runWatch},
+	{name: "serve-metrics", usage: "serve-metrics [flags]", summary: "serve process and socket metrics for Prometheus", run: runServeMetrics},
+	{name: "serve-api", usage: "serve-api [flags]", summary: "serve processes, sockets and signals as a local JSON API", run: runServeAPI},
+	{name: "snapshot", usage: "snapshot [flags]", summary: "capture the processes holding sockets to an archive, replay it with --replay", run: runSnapshot},
+	{name: "diff", usage: "diff [flags] <a> [b]", summary: "compare two snapshots, or a snapshot with the live system", run: runDiff},
+}
+
+func lookupSubcommand(name string) (subcommand, bool) {
+	for _, s := range subcommands {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return subcommand{}, false
+}
+
+func printUsage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintf(out, "Usage:\n  netps [flags]          start the interactive UI\n")
+	for _, s := range subcommands {
+		fmt.Fprintf(out, "  netps %-16s %s\n", s.usage, s.summary)
+	}
+	fmt.Fprintf(out, "\nFlags:\n")
+	fs.PrintDefaults()
+}
+
+// Flag set of a subcommand, errors and usage go to stderr and never exit on their own
+func newFlagSet(sub string, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet("netps "+sub, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage:\n  netps %s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// flag stops at the first positional argument, this lets flags come after them too
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	positionals := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positionals, nil
+		}
+		positionals = append(positionals, args[0])
+		args = args[1:]
+	}
+}
+
+func failf(sub string, format string, args ...any) int {
+	fmt.Fprintf(os.Stderr, "netps %s: %s\n", sub, fmt.Sprintf(format, args...))
+	return exitError
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"netps/internal/snapshot"
+	"netps/internal/socket"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func runDiff(args []string) int {
+	fs := newFlagSet("diff", "diff [flags] <before> [after]\n\nWithout an after archive the before one is compared with the live system.\nSnapshots only hold processes with sockets, one that closed its last socket reads as gone")
+	backend := backendFlags(fs)
+	format := fs.String("format", FormatText, "output format: text or json")
+	positionals, err := parseInterspersed(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positionals) < 1 || len(positionals) > 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *format != FormatText && *format != FormatJSON {
+		return failf("diff", "unknown format %q, expected %s or %s", *format, FormatText, FormatJSON)
+	}
+
+	before, err := snapshot.ReadFile(positionals[0])
+	if err != nil {
+		return failf("diff", "%s: %v", positionals[0], err)
+	}
+	var after snapshot.Snapshot
+	afterLabel := "live"
+	if len(positionals) == 2 {
+		afterLabel = positionals[1]
+		after, err = snapshot.ReadFile(positionals[1])
+	} else {
+		after, err = captureLive(backend)
+	}
+	if err != nil {
+		return failf("diff", "%s: %v", afterLabel, err)
+	}
+
+	d := snapshot.Compare(before, after)
+	if *format == FormatJSON {
+		err = writeJSON(os.Stdout, d)
+	} else {
+		err = writeDiffText(os.Stdout, d, positionals[0], afterLabel)
+	}
+	if err != nil {
+		return failf("diff", "%v", err)
+	}
+	return exitOK
+}
+
+func writeDiffText(w io.Writer, d snapshot.Diff, beforeLabel, afterLabel string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "before:\t%s\t%s\n", beforeLabel, describeMeta(d.Before))
+	fmt.Fprintf(tw, "after:\t%s\t%s\n", afterLabel, describeMeta(d.After))
+	if d.Empty() {
+		fmt.Fprintf(tw, "\nno differences\n")
+		return tw.Flush()
+	}
+
+	section := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(tw, "\n%s:\n", title)
+		for _, l := range lines {
+			fmt.Fprintf(tw, "  %s\n", l)
+		}
+	}
+
+	lines := []string{}
+	for _, p := range d.Appeared {
+		lines = append(lines, "+ "+describeRef(p))
+	}
+	section("processes appeared", lines)
+
+	lines = []string{}
+	for _, p := range d.Gone {
+		lines = append(lines, "- "+describeRef(p))
+	}
+	section("processes gone", lines)
+
+	lines = []string{}
+	for _, c := range d.PortsOpened {
+		lines = append(lines, fmt.Sprintf("+ %s\t%s", describePort(c), describeRefs(c.After)))
+	}
+	section("ports opened", lines)
+
+	lines = []string{}
+	for _, c := range d.PortsClosed {
+		lines = append(lines, fmt.Sprintf("- %s\t%s", describePort(c), describeRefs(c.Before)))
+	}
+	section("ports closed", lines)
+
+	lines = []string{}
+	for _, c := range d.PortsMoved {
+		lines = append(lines, fmt.Sprintf("~ %s\t%s → %s", describePort(c), describeRefs(c.Before), describeRefs(c.After)))
+	}
+	section("ports moved", lines)
+
+	lines = []string{}
+	for _, c := range d.Connections {
+		lines = append(lines, fmt.Sprintf("%s\t%d → %d (%+d)", describeRef(snapshot.ProcessRef{PID: c.PID, Name: c.Name}), c.Before, c.After, c.Delta))
+	}
+	section("established connections", lines)
+
+	return tw.Flush()
+}
+
+func describeMeta(m snapshot.Meta) string {
+	return fmt.Sprintf("%s on %s, %d processes", m.CapturedAt.Local().Format(time.DateTime), m.Hostname, m.Processes)
+}
+
+func describeRef(p snapshot.ProcessRef) string {
+	return fmt.Sprintf("%s (%d)", p.Name, p.PID)
+}
+
+func describeRefs(refs []snapshot.ProcessRef) string {
+	parts := []string{}
+	for _, r := range refs {
+		parts = append(parts, describeRef(r))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describePort(c snapshot.PortChange) string {
+	return c.Proto + " " + socket.Socket{Addr: c.Addr, Port: c.Port}.LocalEndpoint()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"netps/internal/report"
+	"os"
+	"strconv"
+)
+
+func runInspect(args []string) int {
+	fs := newFlagSet("inspect", "inspect [flags] <pid>")
+	backend := backendFlags(fs)
+	format := fs.String("format", FormatJSON, "output format: json or yaml")
+	positionals, err := parseInterspersed(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positionals) != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	pid, err := strconv.Atoi(positionals[0])
+	if err != nil || pid <= 0 {
+		return failf("inspect", "invalid pid %q", positionals[0])
+	}
+	if *format != FormatJSON && *format != FormatYAML {
+		return failf("inspect", "unknown format %q, expected %s or %s", *format, FormatJSON, FormatYAML)
+	}
+
+	processService, socketService, err := newServices(backend)
+	if err != nil {
+		return failf("inspect", "%v", err)
+	}
+
+	inspection := report.Inspect(context.Background(), processService, socketService, pid)
+	if inspection.AllGone() {
+		return failf("inspect", "no process with pid %d", pid)
+	}
+
+	if err := writeDocument(os.Stdout, *format, inspection); err != nil {
+		return failf("inspect", "%v", err)
+	}
+	return exitOK
+}
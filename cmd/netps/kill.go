@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"netps/internal/process"
+	"netps/internal/socket"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	killPollInterval = 200 * time.Millisecond
+	killGrace        = 2 * time.Second // how long SIGKILL gets to release the port
+)
+
+var sigKill, _ = process.LookupSignal(syscall.SIGKILL)
+
+type killTarget struct {
+	PID  int
+	Name string
+}
+
+func (t killTarget) String() string {
+	if t.Name == "" {
+		return fmt.Sprintf("pid %d", t.PID)
+	}
+	return fmt.Sprintf("%s (%d)", t.Name, t.PID)
+}
+
+func runKill(args []string) int {
+	fs := newFlagSet("kill", "kill --port [proto] [addr:]<port> [flags]\n\nExits 3 when nothing is bound to the port")
+	backend := backendFlags(fs)
+	port := fs.String("port", "", "port whose owners get the signal, e.g. 8080, 127.0.0.1:8080 or \"udp 53\"")
+	proto := fs.String("proto", "", "only sockets of this protocol, e.g. tcp (tcp and tcp6), udp6")
+	addr := fs.String("addr", "", "only sockets bound to this address, wildcard listeners match too")
+	signal := fs.String("signal", "TERM", "signal to send, by name (TERM, SIGHUP) or number")
+	dryRun := fs.Bool("dry-run", false, "only report what would be done")
+	wait := fs.Duration("wait", 0, "wait this long for the port to be released, then escalate to SIGKILL (0 does not wait)")
+	positionals, err := parseInterspersed(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positionals) > 0 || *port == "" {
+		fs.Usage()
+		return exitUsage
+	}
+	if *wait < 0 {
+		return failf("kill", "wait must not be negative")
+	}
+
+	sig, err := process.ParseSignal(*signal)
+	if err != nil {
+		return failf("kill", "%v", err)
+	}
+	q, err := socket.ParsePortQuery(*port)
+	if err != nil {
+		return failf("kill", "%v", err)
+	}
+	if *proto != "" {
+		q.Proto = strings.ToLower(*proto)
+	}
+	if *addr != "" {
+		q.Addr = *addr
+	}
+	if err := q.Validate(); err != nil {
+		return failf("kill", "%v", err)
+	}
+
+	processService, socketService, err := newServices(backend)
+	if err != nil {
+		return failf("kill", "%v", err)
+	}
+
+	ctx := context.Background()
+	sockets, err := socketService.FindBound(ctx, q)
+	if err != nil {
+		return failf("kill", "%v", err)
+	}
+	targets := killTargets(ctx, processService, sockets)
+	if len(targets) == 0 {
+		fmt.Fprintf(os.Stderr, "netps kill: nothing bound to %s\n", q)
+		return exitNotFound
+	}
+	for _, t := range targets {
+		fmt.Printf("found %s on %s\n", t, q)
+	}
+
+	escalate := *wait > 0 && sig.Number != syscall.SIGKILL
+	if *dryRun {
+		for _, t := range targets {
+			fmt.Printf("would send %s to %s\n", sig.Name, t)
+		}
+		if escalate {
+			fmt.Printf("would wait up to %s for %s to be released, then send %s\n", *wait, q, sigKill.Name)
+		}
+		return exitOK
+	}
+
+	if !sendToTargets(ctx, processService, targets, sig) {
+		return exitError
+	}
+	if *wait == 0 {
+		return exitOK
+	}
+
+	start := time.Now()
+	remaining, err := waitForRelease(ctx, socketService, q, targets, *wait)
+	if err != nil {
+		return failf("kill", "%v", err)
+	}
+	if len(remaining) == 0 {
+		fmt.Printf("%s released after %s\n", q, time.Since(start).Round(time.Millisecond))
+		return exitOK
+	}
+	if !escalate {
+		fmt.Printf("%s still bound after %s\n", q, *wait)
+		return exitError
+	}
+
+	fmt.Printf("%s still bound after %s, escalating to %s\n", q, *wait, sigKill.Name)
+	sendToTargets(ctx, processService, remaining, sigKill)
+	remaining, err = waitForRelease(ctx, socketService, q, remaining, killGrace)
+	if err != nil {
+		return failf("kill", "%v", err)
+	}
+	if len(remaining) > 0 {
+		fmt.Printf("%s still bound after %s\n", q, sigKill.Name)
+		return exitError
+	}
+	fmt.Printf("%s released after %s\n", q, time.Since(start).Round(time.Millisecond))
+	return exitOK
+}
+
+func killTargets(ctx context.Context, processService *process.Service, sockets []socket.Socket) []killTarget {
+	targets := []killTarget{}
+	seen := map[int]bool{}
+	for _, sock := range sockets {
+		for _, pid := range sock.OwnerPIDs() {
+			if seen[pid] {
+				continue
+			}
+			seen[pid] = true
+			t := killTarget{PID: pid}
+			if name, err := processService.GetProcessName(ctx, pid); err == nil {
+				t.Name = name
+			}
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+// Reports every delivery, returns false when any of them failed. A target that exited
+// meanwhile counts as done, it no longer holds the port either
+func sendToTargets(ctx context.Context, processService *process.Service, targets []killTarget, sig process.Signal) bool {
+	ok := true
+	for _, t := range targets {
+		err := processService.SendSignal(ctx, t.PID, sig.Number)
+		if errors.Is(err, syscall.ESRCH) {
+			fmt.Printf("%s already exited\n", t)
+			continue
+		}
+		if err != nil {
+			fmt.Printf("failed to send %s to %s: %s\n", sig.Name, t, process.DeliveryFailureReason(err))
+			ok = false
+			continue
+		}
+		fmt.Printf("sent %s to %s\n", sig.Name, t)
+	}
+	return ok
+}
+
+// Polls until none of the targets holds the port anymore, returns the ones still
+// holding it at the deadline. A process started meanwhile on the port is not ours to kill
+func waitForRelease(ctx context.Context, socketService *socket.Service, q socket.PortQuery, targets []killTarget, timeout time.Duration) ([]killTarget, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		sockets, err := socketService.FindBound(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		holders := map[int]bool{}
+		for _, sock := range sockets {
+			for _, pid := range sock.OwnerPIDs() {
+				holders[pid] = true
+			}
+		}
+		remaining := []killTarget{}
+		for _, t := range targets {
+			if holders[t.PID] {
+				remaining = append(remaining, t)
+			}
+		}
+		if len(remaining) == 0 || time.Now().After(deadline) {
+			return remaining, nil
+		}
+		time.Sleep(killPollInterval)
+	}
+}
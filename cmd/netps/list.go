@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"netps/internal/process"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	StateListen      = "listen"
+	StateEstablished = "established"
+	StateClose       = "close"
+	StateUnix        = "unix"
+)
+
+type listFilter struct {
+	port  int
+	name  string
+	state string
+	proto string
+}
+
+func runList(args []string) int {
+	fs := newFlagSet("list", "list [flags]")
+	backend := backendFlags(fs)
+	format := fs.String("format", FormatTable, "output format: table, json, ndjson or csv")
+	port := fs.Int("port", 0, "only processes listening on this port")
+	name := fs.String("name", "", "only processes whose name contains this text (case insensitive)")
+	state := fs.String("state", "", "only processes with at least one socket in this state: listen, established, close or unix")
+	proto := fs.String("proto", "", "only processes with a socket of this protocol, e.g. tcp, udp6, unix")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	filter := listFilter{port: *port, name: strings.ToLower(*name), state: *state, proto: strings.ToLower(*proto)}
+	if err := filter.validate(); err != nil {
+		return failf("list", "%v", err)
+	}
+
+	processService, _, err := newServices(backend)
+	if err != nil {
+		return failf("list", "%v", err)
+	}
+
+	summaries, err := processService.GetRunningSummaries(context.Background())
+	if err != nil {
+		return failf("list", "%v", err)
+	}
+
+	matched := []process.ProcessSummary{}
+	for _, p := range summaries {
+		if filter.matches(p) {
+			matched = append(matched, p)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].PID < matched[j].PID
+	})
+
+	if err := writeTabular(os.Stdout, *format, summariesTabular(matched)); err != nil {
+		return failf("list", "%v", err)
+	}
+	return exitOK
+}
+
+func (f listFilter) validate() error {
+	switch f.state {
+	case "", StateListen, StateEstablished, StateClose, StateUnix:
+	default:
+		return fmt.Errorf("unknown state %q, expected %s, %s, %s or %s", f.state, StateListen, StateEstablished, StateClose, StateUnix)
+	}
+	if f.port < 0 || f.port > 65535 {
+		return fmt.Errorf("port %d out of range", f.port)
+	}
+	return nil
+}
+
+func (f listFilter) matches(p process.ProcessSummary) bool {
+	if f.port != 0 && !p.ListensOn(f.port) {
+		return false
+	}
+	if f.name != "" && !strings.Contains(strings.ToLower(p.Name), f.name) {
+		return false
+	}
+	if f.proto != "" && !p.HasProto(f.proto) {
+		return false
+	}
+	switch f.state {
+	case StateListen:
+		return p.LSocketCount > 0
+	case StateEstablished:
+		return p.ESocketCount > 0
+	case StateClose:
+		return p.CSocketCount > 0
+	case StateUnix:
+		return p.USocketCount > 0
+	}
+	return true
+}
+
+func summariesTabular(summaries []process.ProcessSummary) tabular {
+	t := tabular{
+		header:  []string{"PID", "NAME", "LISTEN", "ESTABLISHED", "CLOSE", "UNIX", "LISTEN_PORTS", "PROTOCOLS"},
+		rows:    [][]string{},
+		records: []any{},
+	}
+	for _, p := range summaries {
+		ports := []string{}
+		for _, port := range p.ListenPorts {
+			ports = append(ports, strconv.Itoa(port))
+		}
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(p.PID),
+			p.Name,
+			strconv.Itoa(p.LSocketCount),
+			strconv.Itoa(p.ESocketCount),
+			strconv.Itoa(p.CSocketCount),
+			strconv.Itoa(p.USocketCount),
+			strings.Join(ports, " "),
+			strings.Join(p.Protos, " "),
+		})
+		t.records = append(t.records, p)
+	}
+	return t
+}
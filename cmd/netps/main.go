@@ -1,25 +1,72 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"time"
 
+	"netps/internal/process"
+	"netps/internal/socket"
 	"netps/internal/ui"
 
 	tea "charm.land/bubbletea/v2"
 )
 
 func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// Without a subcommand netps starts the interactive UI
+func run(args []string) int {
+	if len(args) > 0 {
+		if sub, ok := lookupSubcommand(args[0]); ok {
+			return sub.run(args[1:])
+		}
+	}
+	return runTUI(args)
+}
+
+func runTUI(args []string) int {
+	fs := flag.NewFlagSet("netps", flag.ContinueOnError)
+	fs.Usage = func() { printUsage(fs) }
+	backend := backendFlags(fs)
+	refreshInterval := fs.Duration("refresh", 2*time.Second, "auto-refresh interval of the process list and detail screens, 0 disables it")
+	replay := fs.String("replay", "", "browse a snapshot archive instead of the live system")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if *refreshInterval < 0 {
+		fmt.Printf("Alas, there's been an error: refresh interval must not be negative")
+		return exitError
+	}
+
+	cfg := ui.Config{RefreshInterval: *refreshInterval}
+	var processService *process.Service
+	var socketService *socket.Service
+	var err error
+	if *replay != "" {
+		// a snapshot never changes, refreshing it would only redraw the same rows
+		processService, socketService, cfg.SnapshotAt, err = newReplayServices(*replay)
+		cfg.RefreshInterval = 0
+	} else {
+		processService, socketService, err = newServices(backend)
+	}
+	if err != nil {
+		fmt.Printf("Alas, there's been an error: %v", err)
+		return exitError
+	}
 
-	root, err := ui.New()
+	root, err := ui.New(processService, socketService, cfg)
 	if err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
+		return exitError
 	}
 
 	p := tea.NewProgram(root)
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
+		return exitError
 	}
+	return exitOK
 }
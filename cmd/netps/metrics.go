@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"netps/internal/metrics"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+func runServeMetrics(args []string) int {
+	fs := newFlagSet("serve-metrics", "serve-metrics [flags]")
+	backend := backendFlags(fs)
+	listen := fs.String("listen", ":9731", "address to serve on")
+	path := fs.String("path", "/metrics", "path of the metrics endpoint")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	processService, socketService, err := newServices(backend)
+	if err != nil {
+		return failf("serve-metrics", "%v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+*path, metrics.NewExporter(processService, socketService))
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "netps exporter, metrics are at %s\n", *path)
+	})
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return serveUntilSignal("serve-metrics", server, func() error { return server.ListenAndServe() })
+}
+
+// Runs the server until SIGINT or SIGTERM, then gives in-flight requests a moment to finish
+func serveUntilSignal(sub string, server *http.Server, serve func() error) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- serve()
+	}()
+	fmt.Fprintf(os.Stderr, "netps %s: serving on %s\n", sub, server.Addr)
+
+	select {
+	case err := <-errs:
+		return failf(sub, "%v", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return failf(sub, "%v", err)
+	}
+	return exitOK
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	FormatTable  = "table"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatYAML   = "yaml"
+)
+
+// Output formats for tabular results. Table and CSV take the header and one row per
+// record, JSON and NDJSON marshal the records themselves
+type tabular struct {
+	header  []string
+	rows    [][]string
+	records []any
+}
+
+func writeTabular(w io.Writer, format string, t tabular) error {
+	switch format {
+	case FormatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(t.header); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(t.rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	case FormatJSON:
+		return writeJSON(w, t.records)
+	case FormatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, r := range t.records {
+			if err := enc.Encode(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q, expected %s, %s, %s or %s", format, FormatTable, FormatJSON, FormatNDJSON, FormatCSV)
+	}
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// Output formats for a single document
+func writeDocument(w io.Writer, format string, v any) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, v)
+	case FormatYAML:
+		return writeYAML(w, v)
+	default:
+		return fmt.Errorf("unknown format %q, expected %s or %s", format, FormatJSON, FormatYAML)
+	}
+}
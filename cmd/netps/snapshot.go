@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"netps/internal/process"
+	"netps/internal/procfs"
+	"netps/internal/snapshot"
+	"netps/internal/socket"
+	"netps/internal/sysconf"
+	"os"
+	"time"
+)
+
+func runSnapshot(args []string) int {
+	fs := newFlagSet("snapshot", "snapshot [flags]\n\nOnly processes holding at least one socket are captured")
+	backend := backendFlags(fs)
+	output := fs.String("o", "", "archive to write, netps-snapshot-<time>.tar.gz when empty")
+	raw := fs.Bool("raw", false, "also store the raw /proc files that were read")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	snap, err := captureLive(backend)
+	if err != nil {
+		return failf("snapshot", "%v", err)
+	}
+
+	rawFiles := []procfs.RawFile{}
+	if *raw {
+		fsys, err := backend.procFS()
+		if err != nil {
+			return failf("snapshot", "%v", err)
+		}
+		var errs []error
+		rawFiles, errs = procfs.CollectRawFiles(fsys, snap.PIDs())
+		if len(errs) > 0 {
+			fmt.Fprintf(os.Stderr, "netps snapshot: %d raw files could not be read, first: %v\n", len(errs), errs[0])
+		}
+	}
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("netps-snapshot-%s.tar.gz", snap.CapturedAt.Local().Format("20060102-150405"))
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return failf("snapshot", "%v", err)
+	}
+	if err := snapshot.WriteArchive(f, snap, rawFiles); err != nil {
+		f.Close()
+		return failf("snapshot", "%v", err)
+	}
+	if err := f.Close(); err != nil {
+		return failf("snapshot", "%v", err)
+	}
+	fmt.Fprintf(os.Stderr, "netps snapshot: %d processes written to %s\n", len(snap.Processes), path)
+	return exitOK
+}
+
+func captureLive(backend *backendOptions) (snapshot.Snapshot, error) {
+	procfsClient, err := newProcfsClient(backend)
+	if err != nil {
+		return snapshot.Snapshot{}, err
+	}
+	sysconfClient := sysconf.NewClient()
+	src := snapshot.Sources{
+		Sockets:   procfsClient,
+		Name:      procfsClient,
+		Detail:    procfsClient,
+		Resource:  procfsClient,
+		User:      procfsClient,
+		ClockTick: sysconfClient,
+		PageSize:  sysconfClient,
+		UpTime:    procfsClient,
+	}
+	return snapshot.Capture(context.Background(), src)
+}
+
+func newReplayServices(path string) (*process.Service, *socket.Service, time.Time, error) {
+	snap, err := snapshot.ReadFile(path)
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	src := snapshot.NewSource(snap)
+	cfg := process.Config{
+		Process:   src,
+		Name:      src,
+		Detail:    src,
+		Clocktick: src,
+		PageSize:  src,
+		UpTime:    src,
+		Resource:  src,
+		User:      src,
+		Signal:    src,
+	}
+	return process.NewProcessService(cfg), socket.NewService(src), snap.CapturedAt, nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"netps/internal/process"
+	"netps/internal/report"
+	"netps/internal/socket"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const FormatText = "text"
+
+type watchEvent struct {
+	Time      time.Time     `json:"time"`
+	Event     string        `json:"event"`
+	PID       int           `json:"pid"`
+	Name      string        `json:"name"`
+	PrevState string        `json:"prev_state,omitempty"`
+	Socket    report.Socket `json:"socket"`
+}
+
+type watchFilter struct {
+	listenOnly  bool
+	includeUnix bool
+}
+
+func runWatch(args []string) int {
+	fs := newFlagSet("watch", "watch [flags]")
+	backend := backendFlags(fs)
+	format := fs.String("format", FormatText, "output format: text or ndjson")
+	interval := fs.Duration("interval", time.Second, "time between snapshots")
+	listenOnly := fs.Bool("listen", false, "only listeners and bound datagram sockets")
+	includeUnix := fs.Bool("unix", false, "include unix domain sockets")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *format != FormatText && *format != FormatNDJSON {
+		return failf("watch", "unknown format %q, expected %s or %s", *format, FormatText, FormatNDJSON)
+	}
+	if *interval <= 0 {
+		return failf("watch", "interval must be positive")
+	}
+
+	processService, socketService, err := newServices(backend)
+	if err != nil {
+		return failf("watch", "%v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	filter := watchFilter{listenOnly: *listenOnly, includeUnix: *includeUnix}
+	prev, err := socketService.GetRunningSockets(ctx)
+	if err != nil {
+		return failf("watch", "%v", err)
+	}
+	prev = filter.apply(prev)
+
+	names := processNames{}
+	names.learn(ctx, processService, prev)
+	fmt.Fprintf(os.Stderr, "netps watch: following %d processes every %s, ctrl+c to stop\n", len(prev), *interval)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case now := <-ticker.C:
+			next, err := socketService.GetRunningSockets(ctx)
+			if err != nil {
+				// a failed snapshot is skipped, the next one diffs against the last good one
+				fmt.Fprintf(os.Stderr, "netps watch: %v\n", err)
+				continue
+			}
+			next = filter.apply(next)
+			names.learn(ctx, processService, next)
+
+			for _, e := range socket.Diff(prev, next) {
+				if err := writeWatchEvent(os.Stdout, *format, newWatchEvent(now, e, names[e.PID])); err != nil {
+					return failf("watch", "%v", err)
+				}
+			}
+			names.forget(next)
+			prev = next
+		}
+	}
+}
+
+func (f watchFilter) apply(snapshot map[int][]socket.Socket) map[int][]socket.Socket {
+	out := map[int][]socket.Socket{}
+	for pid, sockets := range snapshot {
+		kept := []socket.Socket{}
+		for _, s := range sockets {
+			if s.IsUnix() && !f.includeUnix {
+				continue
+			}
+			if f.listenOnly && !s.IsBound() && !(s.IsUnix() && s.State == socket.StateListen) {
+				continue
+			}
+			kept = append(kept, s)
+		}
+		if len(kept) > 0 {
+			out[pid] = kept
+		}
+	}
+	return out
+}
+
+// Names are resolved once per PID and kept while the PID has sockets,
+// so a closing event can still be attributed after the process exited
+type processNames map[int]string
+
+func (n processNames) learn(ctx context.Context, processService *process.Service, snapshot map[int][]socket.Socket) {
+	for pid := range snapshot {
+		if _, ok := n[pid]; ok {
+			continue
+		}
+		name, err := processService.GetProcessName(ctx, pid)
+		if err != nil {
+			name = ""
+		}
+		n[pid] = name
+	}
+}
+
+func (n processNames) forget(snapshot map[int][]socket.Socket) {
+	for pid := range n {
+		if _, ok := snapshot[pid]; !ok {
+			delete(n, pid)
+		}
+	}
+}
+
+func newWatchEvent(now time.Time, e socket.Event, name string) watchEvent {
+	return watchEvent{
+		Time:      now,
+		Event:     string(e.Kind),
+		PID:       e.PID,
+		Name:      name,
+		PrevState: string(e.PrevState),
+		Socket:    report.NewSocket(e.Socket),
+	}
+}
+
+func writeWatchEvent(w io.Writer, format string, e watchEvent) error {
+	if format == FormatNDJSON {
+		return json.NewEncoder(w).Encode(e)
+	}
+	_, err := fmt.Fprintln(w, e.text())
+	return err
+}
+
+// e.g. "12:04:05 pid 1234 (nginx) LISTEN tcp 0.0.0.0:80 opened"
+func (e watchEvent) text() string {
+	who := fmt.Sprintf("pid %d", e.PID)
+	if e.Name != "" {
+		who += " (" + e.Name + ")"
+	}
+	sock := socket.Socket{
+		Proto:      e.Socket.Proto,
+		Type:       e.Socket.Type,
+		Addr:       e.Socket.LocalAddr,
+		Port:       e.Socket.LocalPort,
+		RemoteAddr: e.Socket.RemoteAddr,
+		RemotePort: e.Socket.RemotePort,
+		Path:       e.Socket.Path,
+	}
+	what := e.Event
+	if e.PrevState != "" {
+		what = "was " + e.PrevState
+	}
+	return fmt.Sprintf("%s %s %s %s %s %s", e.Time.Format(time.TimeOnly), who, e.Socket.State, sock.ProtoLabel(), sock.Endpoints(), what)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"netps/internal/process"
+	"netps/internal/socket"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Distinct from exitError so scripts can tell a free port from a failed lookup
+const exitNotFound = 3
+
+type whoRecord struct {
+	PID       int    `json:"pid"`
+	Name      string `json:"name"`
+	User      string `json:"user"`
+	UID       int    `json:"uid"`
+	Command   string `json:"command"`
+	Proto     string `json:"proto"`
+	State     string `json:"state"`
+	LocalAddr string `json:"local_addr"`
+	LocalPort int    `json:"local_port"`
+}
+
+func runWho(args []string) int {
+	fs := newFlagSet("who", "who [flags] [addr:]<port>\n\nExits 3 when nothing is bound to the port")
+	backend := backendFlags(fs)
+	format := fs.String("format", FormatTable, "output format: table or json")
+	proto := fs.String("proto", "", "only sockets of this protocol, e.g. tcp (tcp and tcp6), udp6")
+	addr := fs.String("addr", "", "only sockets bound to this address, wildcard listeners match too")
+	positionals, err := parseInterspersed(fs, args)
+	if err != nil {
+		return exitUsage
+	}
+	if len(positionals) != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *format != FormatTable && *format != FormatJSON {
+		return failf("who", "unknown format %q, expected %s or %s", *format, FormatTable, FormatJSON)
+	}
+
+	q, err := socket.ParsePortQuery(positionals[0])
+	if err != nil {
+		return failf("who", "%v", err)
+	}
+	if *proto != "" {
+		q.Proto = strings.ToLower(*proto)
+	}
+	if *addr != "" {
+		q.Addr = *addr
+	}
+	if err := q.Validate(); err != nil {
+		return failf("who", "%v", err)
+	}
+
+	processService, socketService, err := newServices(backend)
+	if err != nil {
+		return failf("who", "%v", err)
+	}
+
+	ctx := context.Background()
+	sockets, err := socketService.FindBound(ctx, q)
+	if err != nil {
+		return failf("who", "%v", err)
+	}
+	if len(sockets) == 0 {
+		fmt.Fprintf(os.Stderr, "netps who: nothing bound to %s\n", q)
+		return exitNotFound
+	}
+
+	records := whoRecords(ctx, processService, sockets)
+	switch *format {
+	case FormatJSON:
+		err = writeJSON(os.Stdout, records)
+	default:
+		err = writeTabular(os.Stdout, FormatTable, whoTabular(records))
+	}
+	if err != nil {
+		return failf("who", "%v", err)
+	}
+	return exitOK
+}
+
+// One record per socket and owner, details that can't be read (another user's
+// process, or one that just exited) are left empty rather than failing the lookup
+func whoRecords(ctx context.Context, processService *process.Service, sockets []socket.Socket) []whoRecord {
+	records := []whoRecord{}
+	for _, sock := range sockets {
+		for _, pid := range sock.OwnerPIDs() {
+			r := whoRecord{
+				PID:       pid,
+				UID:       -1,
+				Proto:     sock.Proto,
+				State:     string(sock.State),
+				LocalAddr: sock.Addr,
+				LocalPort: sock.Port,
+			}
+			if name, err := processService.GetProcessName(ctx, pid); err == nil {
+				r.Name = name
+			}
+			if detail, err := processService.GetProcessDetail(ctx, pid); err == nil {
+				r.Command = detail.Command
+			}
+			if user, err := processService.GetUser(ctx, pid); err == nil {
+				r.User, r.UID = user.Name, user.RealUID
+			}
+			records = append(records, r)
+		}
+	}
+	return records
+}
+
+func whoTabular(records []whoRecord) tabular {
+	t := tabular{header: []string{"PID", "NAME", "USER", "PROTO", "LOCAL", "COMMAND"}}
+	for _, r := range records {
+		local := socket.Socket{Addr: r.LocalAddr, Port: r.LocalPort}.LocalEndpoint()
+		t.rows = append(t.rows, []string{strconv.Itoa(r.PID), r.Name, r.User, r.Proto, local, r.Command})
+	}
+	return t
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Just enough YAML for our reports: block style, keys named after the json
+// tags, strings quoted only when a plain scalar would be read back differently
+func writeYAML(w io.Writer, v any) error {
+	inline, lines := yamlNode(reflect.ValueOf(v))
+	if lines == nil {
+		lines = []string{inline}
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// Returns either an inline scalar or, for non empty collections, the block lines
+func yamlNode(v reflect.Value) (string, []string) {
+	if !v.IsValid() {
+		return "null", nil
+	}
+	if v.Type().Implements(textMarshalerType) && (v.Kind() != reflect.Pointer || !v.IsNil()) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return yamlString(err.Error()), nil
+		}
+		return yamlString(string(text)), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return "null", nil
+		}
+		return yamlNode(v.Elem())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), nil
+	case reflect.String:
+		return yamlString(v.String()), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return "null", nil
+		}
+		if v.Len() == 0 {
+			return "[]", nil
+		}
+		lines := []string{}
+		for i := range v.Len() {
+			inline, block := yamlNode(v.Index(i))
+			if block == nil {
+				lines = append(lines, "- "+inline)
+				continue
+			}
+			lines = append(lines, "- "+block[0])
+			for _, l := range block[1:] {
+				lines = append(lines, "  "+l)
+			}
+		}
+		return "", lines
+	case reflect.Map:
+		if v.IsNil() {
+			return "null", nil
+		}
+		if v.Len() == 0 {
+			return "{}", nil
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		lines := []string{}
+		for _, k := range keys {
+			lines = appendYAMLField(lines, fmt.Sprint(k.Interface()), v.MapIndex(k))
+		}
+		return "", lines
+	case reflect.Struct:
+		lines := []string{}
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" && opts == "" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			fv := v.Field(i)
+			if strings.Contains(opts, "omitempty") && isEmptyValue(fv) {
+				continue
+			}
+			lines = appendYAMLField(lines, name, fv)
+		}
+		if len(lines) == 0 {
+			return "{}", nil
+		}
+		return "", lines
+	default:
+		return yamlString(fmt.Sprint(v.Interface())), nil
+	}
+}
+
+func appendYAMLField(lines []string, key string, v reflect.Value) []string {
+	inline, block := yamlNode(v)
+	if block == nil {
+		return append(lines, yamlString(key)+": "+inline)
+	}
+	lines = append(lines, yamlString(key)+":")
+	for _, l := range block {
+		lines = append(lines, "  "+l)
+	}
+	return lines
+}
+
+// Mirrors encoding/json's notion of empty for omitempty
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	default:
+		return v.IsZero()
+	}
+}
+
+func yamlString(s string) string {
+	if needsYAMLQuotes(s) {
+		// Go escapes (\n, \t, \xNN, \uNNNN) are all valid in YAML double quoted scalars
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func needsYAMLQuotes(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "~", "null", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return true // 0x1f and 0o17 are ints to a YAML reader
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return true
+	}
+	for _, r := range s {
+		if !strconv.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
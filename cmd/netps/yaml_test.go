@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAMLString(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"nginx", "nginx"},
+		{"/usr/sbin/nginx -g daemon off;", "/usr/sbin/nginx -g daemon off;"},
+		{"", `""`},
+		{"yes", `"yes"`},
+		{"No", `"No"`},
+		{"null", `"null"`},
+		{"~", `"~"`},
+		{"0755", `"0755"`},
+		{"0x1f", `"0x1f"`},
+		{"1e3", `"1e3"`},
+		{"a: b", `"a: b"`},
+		{"key:", `"key:"`},
+		{"a:b", "a:b"},
+		{"nginx #1", `"nginx #1"`},
+		{" #", `" #"`},
+		{"a#b", "a#b"},
+		{" padded", `" padded"`},
+		{"- item", `"- item"`},
+		{"@host", `"@host"`},
+		{"tab\there", `"tab\there"`},
+	}
+	for _, c := range cases {
+		if got := yamlString(c.in); got != c.want {
+			t.Errorf("yamlString(%q) = %s, want %s", c.in, got, c.want)
+		}
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	type owner struct {
+		PID int    `json:"pid"`
+		FD  int    `json:"fd,omitempty"`
+		Tag string `json:"tag,omitempty"`
+	}
+	type record struct {
+		Name   string     `json:"name"`
+		Ports  []int      `json:"ports"`
+		Groups [][]string `json:"groups"`
+		Owners []owner    `json:"owners"`
+		Labels []string   `json:"labels"`
+		Parent *owner     `json:"parent"`
+		Hidden string     `json:"-"`
+	}
+	cases := []struct {
+		name string
+		in   any
+		want []string
+	}{
+		{
+			name: "scalar",
+			in:   "yes",
+			want: []string{`"yes"`},
+		},
+		{
+			name: "nested slices",
+			in:   [][]string{{"a: b", "0755"}, {}, {""}},
+			want: []string{
+				`- - "a: b"`,
+				`  - "0755"`,
+				`- []`,
+				`- - ""`,
+			},
+		},
+		{
+			name: "struct",
+			in: record{
+				Name:   "nginx: master",
+				Ports:  []int{80, 443},
+				Groups: [][]string{{"www-data", "on"}},
+				Owners: []owner{{PID: 812, FD: 6}, {PID: 813, Tag: " #"}},
+				Hidden: "left out",
+			},
+			want: []string{
+				`name: "nginx: master"`,
+				`ports:`,
+				`  - 80`,
+				`  - 443`,
+				`groups:`,
+				`  - - www-data`,
+				`    - "on"`,
+				`owners:`,
+				`  - pid: 812`,
+				`    fd: 6`,
+				`  - pid: 813`,
+				`    tag: " #"`,
+				`labels: null`,
+				`parent: null`,
+			},
+		},
+		{
+			name: "map",
+			in:   map[string]int{"b": 2, "a": 1, "": 0},
+			want: []string{`"": 0`, `a: 1`, `b: 2`},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := writeYAML(&b, c.in); err != nil {
+				t.Fatal(err)
+			}
+			if want := strings.Join(c.want, "\n") + "\n"; b.String() != want {
+				t.Errorf("got:\n%s\nwant:\n%s", b.String(), want)
+			}
+		})
+	}
+}
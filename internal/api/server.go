@@ -0,0 +1,200 @@
+// Package api serves process and socket data as JSON over HTTP.
+//
+//	GET  /v1/processes               processes holding sockets
+//	GET  /v1/processes/{pid}         full report, one status per section
+//	GET  /v1/processes/{pid}/sockets sockets of a process
+//	POST /v1/processes/{pid}/signal  {"signal": "TERM"}, needs the bearer token
+//
+// Errors are {"error": "..."} with a matching status code. Requests must name a loopback
+// host, a page on another origin resolving its name to 127.0.0.1 (DNS rebinding) is refused
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"netps/internal/process"
+	"netps/internal/report"
+	"netps/internal/socket"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const maxBodyBytes = 1 << 12
+
+type Config struct {
+	Token   string // required by the signal endpoint, which is disabled when empty
+	AnyHost bool   // skips the Host check, for a unix socket no browser can reach
+}
+
+type Server struct {
+	processService *process.Service
+	socketService  *socket.Service
+	token          string
+	anyHost        bool
+	mux            *http.ServeMux
+}
+
+func NewServer(processService *process.Service, socketService *socket.Service, cfg Config) *Server {
+	s := &Server{
+		processService: processService,
+		socketService:  socketService,
+		token:          cfg.Token,
+		anyHost:        cfg.AnyHost,
+		mux:            http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /v1/processes", s.listProcesses)
+	s.mux.HandleFunc("GET /v1/processes/{pid}", s.getProcess)
+	s.mux.HandleFunc("GET /v1/processes/{pid}/sockets", s.getSockets)
+	s.mux.HandleFunc("POST /v1/processes/{pid}/signal", s.sendSignal)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.anyHost && !loopbackHost(r.Host) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not a loopback name or address", r.Host))
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func loopbackHost(hostport string) bool {
+	host, _, err := net.SplitHostPort(hostport)
+	if err != nil {
+		host = strings.TrimSuffix(strings.TrimPrefix(hostport, "["), "]") // no port
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) listProcesses(w http.ResponseWriter, r *http.Request) {
+	summaries, err := s.processService.GetRunningSummaries(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].PID < summaries[j].PID
+	})
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+func (s *Server) getProcess(w http.ResponseWriter, r *http.Request) {
+	pid, ok := pathPID(w, r)
+	if !ok {
+		return
+	}
+	inspection := report.Inspect(r.Context(), s.processService, s.socketService, pid)
+	if inspection.AllGone() {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no process with pid %d", pid))
+		return
+	}
+	writeJSON(w, http.StatusOK, inspection)
+}
+
+func (s *Server) getSockets(w http.ResponseWriter, r *http.Request) {
+	pid, ok := pathPID(w, r)
+	if !ok {
+		return
+	}
+	sockets, err := s.socketService.GetSocketsByStates(r.Context(), pid, report.SocketStates)
+	if report.IsGone(err) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no process with pid %d", pid))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, report.NewSockets(sockets))
+}
+
+type signalRequest struct {
+	Signal string `json:"signal"`
+}
+
+type signalResponse struct {
+	PID    int    `json:"pid"`
+	Signal string `json:"signal"`
+	Sent   bool   `json:"sent"`
+}
+
+func (s *Server) sendSignal(w http.ResponseWriter, r *http.Request) {
+	if s.token == "" {
+		writeError(w, http.StatusForbidden, errors.New("signals are disabled, start the server with a token"))
+		return
+	}
+	if !s.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+		return
+	}
+	pid, ok := pathPID(w, r)
+	if !ok {
+		return
+	}
+
+	var req signalRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodyBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid body: %w", err))
+		return
+	}
+	sig, err := process.ParseSignal(req.Signal)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	err = s.processService.SendSignal(r.Context(), pid, sig.Number)
+	switch {
+	case errors.Is(err, syscall.ESRCH):
+		writeError(w, http.StatusNotFound, fmt.Errorf("no process with pid %d", pid))
+	case errors.Is(err, syscall.EPERM):
+		writeError(w, http.StatusForbidden, fmt.Errorf("%s to %d: %s", sig.Name, pid, process.DeliveryFailureReason(err)))
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	default:
+		slog.Info("Server.sendSignal() -> delivered", "pid", pid, "signal", sig.Name)
+		writeJSON(w, http.StatusOK, signalResponse{PID: pid, Signal: sig.Name, Sent: true})
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func pathPID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	pid, err := strconv.Atoi(r.PathValue("pid"))
+	if err != nil || pid <= 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid pid %q", r.PathValue("pid")))
+		return 0, false
+	}
+	return pid, true
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("api.writeJSON() -> encoding failed", "msg", err.Error())
+	}
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"netps/internal/process"
+	"netps/internal/snapshot"
+	"netps/internal/socket"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+const token = "s3cret"
+
+// host replays a snapshot and records signals instead of sending them
+type host struct {
+	*snapshot.Source
+	refuse    map[int]error
+	delivered []int
+}
+
+func (h *host) Signal(ctx context.Context, pid int, sig syscall.Signal) error {
+	if err := h.refuse[pid]; err != nil {
+		return err
+	}
+	h.delivered = append(h.delivered, pid)
+	return nil
+}
+
+func newHost() *host {
+	return &host{
+		Source: snapshot.NewSource(snapshot.Snapshot{
+			Version:   snapshot.Version,
+			ClockTick: 100,
+			PageSize:  4096,
+			UpTime:    86400,
+			Processes: []snapshot.Process{{
+				PID:      812,
+				Name:     "nginx",
+				Resource: &process.ProcessResource{StartTimeTick: 6000},
+				Sockets:  []socket.Socket{{Proto: socket.ProtoTCP, Addr: "0.0.0.0", Port: 80, State: socket.StateListen, Inode: 1}},
+			}},
+		}),
+		refuse: map[int]error{1: syscall.EPERM, 4242: syscall.ESRCH},
+	}
+}
+
+func serve(t *testing.T, h *host, cfg Config) *httptest.Server {
+	t.Helper()
+	processService := process.NewProcessService(process.Config{
+		Process:   h,
+		Name:      h,
+		Detail:    h,
+		Clocktick: h,
+		PageSize:  h,
+		UpTime:    h,
+		Resource:  h,
+		User:      h,
+		Signal:    h,
+	})
+	server := httptest.NewServer(NewServer(processService, socket.NewService(h), cfg))
+	t.Cleanup(server.Close)
+	return server
+}
+
+type call struct {
+	method, path, host, auth, body string
+}
+
+func do(t *testing.T, server *httptest.Server, c call) (int, http.Header, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(c.method, server.URL+c.path, strings.NewReader(c.body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.host != "" {
+		req.Host = c.host
+	}
+	if c.auth != "" {
+		req.Header.Set("Authorization", c.auth)
+	}
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var body any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("%s %s: %v", c.method, c.path, err)
+	}
+	obj, _ := body.(map[string]any)
+	return resp.StatusCode, resp.Header, obj
+}
+
+func TestReadEndpoints(t *testing.T) {
+	server := serve(t, newHost(), Config{})
+	cases := []struct {
+		path   string
+		status int
+	}{
+		{"/v1/processes", http.StatusOK},
+		{"/v1/processes/812", http.StatusOK},
+		{"/v1/processes/812/sockets", http.StatusOK},
+		{"/v1/processes/4242", http.StatusNotFound},
+		{"/v1/processes/4242/sockets", http.StatusNotFound},
+		{"/v1/processes/nginx", http.StatusBadRequest},
+		{"/v1/processes/-1", http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		status, header, body := do(t, server, call{method: http.MethodGet, path: c.path})
+		if status != c.status {
+			t.Errorf("GET %s = %d %v, want %d", c.path, status, body, c.status)
+		}
+		if got := header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("GET %s Content-Type %q", c.path, got)
+		}
+	}
+}
+
+func TestHostCheck(t *testing.T) {
+	cases := []struct {
+		host    string
+		anyHost bool
+		status  int
+	}{
+		{"localhost:9732", false, http.StatusOK},
+		{"LOCALHOST", false, http.StatusOK},
+		{"127.0.0.1:9732", false, http.StatusOK},
+		{"127.1.2.3", false, http.StatusOK},
+		{"[::1]:9732", false, http.StatusOK},
+		{"[::1]", false, http.StatusOK},
+		{"attacker.example:9732", false, http.StatusForbidden},
+		{"localhost.attacker.example", false, http.StatusForbidden},
+		{"10.0.0.5:9732", false, http.StatusForbidden},
+		{"attacker.example", true, http.StatusOK},
+	}
+	for _, c := range cases {
+		server := serve(t, newHost(), Config{AnyHost: c.anyHost})
+		status, _, body := do(t, server, call{method: http.MethodGet, path: "/v1/processes/812", host: c.host})
+		if status != c.status {
+			t.Errorf("Host %q (any host %v) = %d %v, want %d", c.host, c.anyHost, status, body, c.status)
+		}
+	}
+}
+
+func TestSignal(t *testing.T) {
+	cases := []struct {
+		name   string
+		token  string
+		call   call
+		status int
+	}{
+		{"disabled without a token", "", call{path: "/v1/processes/812/signal", auth: "Bearer " + token, body: `{"signal": "TERM"}`}, http.StatusForbidden},
+		{"no credentials", token, call{path: "/v1/processes/812/signal", body: `{"signal": "TERM"}`}, http.StatusUnauthorized},
+		{"wrong token", token, call{path: "/v1/processes/812/signal", auth: "Bearer nope", body: `{"signal": "TERM"}`}, http.StatusUnauthorized},
+		{"not a bearer token", token, call{path: "/v1/processes/812/signal", auth: "Basic " + token, body: `{"signal": "TERM"}`}, http.StatusUnauthorized},
+		{"invalid pid", token, call{path: "/v1/processes/0/signal", auth: "Bearer " + token, body: `{"signal": "TERM"}`}, http.StatusBadRequest},
+		{"invalid body", token, call{path: "/v1/processes/812/signal", auth: "Bearer " + token, body: `TERM`}, http.StatusBadRequest},
+		{"unknown signal", token, call{path: "/v1/processes/812/signal", auth: "Bearer " + token, body: `{"signal": "SIGNOPE"}`}, http.StatusBadRequest},
+		{"process gone", token, call{path: "/v1/processes/4242/signal", auth: "Bearer " + token, body: `{"signal": "TERM"}`}, http.StatusNotFound},
+		{"not permitted", token, call{path: "/v1/processes/1/signal", auth: "Bearer " + token, body: `{"signal": "TERM"}`}, http.StatusForbidden},
+		{"sent", token, call{path: "/v1/processes/812/signal", auth: "Bearer " + token, body: `{"signal": "HUP"}`}, http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := newHost()
+			server := serve(t, h, Config{Token: c.token})
+			c.call.method = http.MethodPost
+			status, header, body := do(t, server, c.call)
+			if status != c.status {
+				t.Fatalf("status %d %v, want %d", status, body, c.status)
+			}
+			if status == http.StatusUnauthorized && header.Get("WWW-Authenticate") != "Bearer" {
+				t.Errorf("WWW-Authenticate %q, want Bearer", header.Get("WWW-Authenticate"))
+			}
+			if status != http.StatusOK {
+				if _, ok := body["error"].(string); !ok {
+					t.Errorf("body %v has no error", body)
+				}
+				if len(h.delivered) > 0 {
+					t.Errorf("delivered to %v on a refused request", h.delivered)
+				}
+				return
+			}
+			if body["sent"] != true || body["signal"] != "SIGHUP" || body["pid"] != 812.0 {
+				t.Errorf("body %v", body)
+			}
+			if len(h.delivered) != 1 || h.delivered[0] != 812 {
+				t.Errorf("delivered to %v, want 812", h.delivered)
+			}
+		})
+	}
+}
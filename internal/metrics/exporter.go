@@ -0,0 +1,184 @@
+// Package metrics exposes process and socket data in the Prometheus text exposition format.
+// Every scrape takes a fresh snapshot, nothing is cached between scrapes
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"netps/internal/process"
+	"netps/internal/socket"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type Exporter struct {
+	processService *process.Service
+	socketService  *socket.Service
+}
+
+func NewExporter(processService *process.Service, socketService *socket.Service) *Exporter {
+	return &Exporter{
+		processService: processService,
+		socketService:  socketService,
+	}
+}
+
+// The body is rendered before anything is written so a failed scrape is a clean 500
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	if err := e.Write(r.Context(), &buf); err != nil {
+		slog.Error("Exporter.ServeHTTP() -> scrape failed", "msg", err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.Write(buf.Bytes())
+}
+
+// Write takes a snapshot and renders every metric family. Only the socket snapshot
+// is required, a process whose resources can't be read is left out of those families
+func (e *Exporter) Write(ctx context.Context, w io.Writer) error {
+	start := time.Now()
+	running, err := e.socketService.GetRunningSockets(ctx)
+	if err != nil {
+		return err
+	}
+
+	pids := make([]int, 0, len(running))
+	for pid := range running {
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+
+	clockTick, _ := e.processService.GetClockTick(ctx)
+
+	processes := newFamily("netps_processes", "gauge", "Processes holding at least one socket.")
+	sockets := newFamily("netps_process_sockets", "gauge", "Sockets held by a process by protocol and state.")
+	listening := newFamily("netps_process_listen_port_info", "gauge", "Ports a process listens on, or has bound for datagram protocols.")
+	rss := newFamily("netps_process_resident_memory_bytes", "gauge", "Resident set size of a process.")
+	vsz := newFamily("netps_process_virtual_memory_bytes", "gauge", "Virtual memory size of a process.")
+	cpu := newFamily("netps_process_cpu_seconds_total", "counter", "CPU time consumed by a process by mode.")
+	duration := newFamily("netps_scrape_duration_seconds", "gauge", "Time taken to collect this scrape.")
+
+	// only the processes with series below are counted, not those gone before their name was read
+	emitted := 0
+	for _, pid := range pids {
+		name, err := e.processService.GetProcessName(ctx, pid)
+		if err != nil {
+			continue // exited since the snapshot
+		}
+		emitted++
+		base := []label{{"pid", strconv.Itoa(pid)}, {"name", name}}
+
+		counts := map[[2]string]int{}
+		// SO_REUSEPORT sockets share one label set, an info series is exposed once
+		bound := map[[3]string]bool{}
+		for _, s := range running[pid] {
+			counts[[2]string{s.Proto, string(s.State)}]++
+			key := [3]string{s.Proto, s.Addr, strconv.Itoa(s.Port)}
+			if s.IsBound() && !bound[key] {
+				bound[key] = true
+				listening.add(withLabels(base, label{"proto", key[0]}, label{"addr", key[1]}, label{"port", key[2]}), 1)
+			}
+		}
+		keys := make([][2]string, 0, len(counts))
+		for k := range counts {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i][0] != keys[j][0] {
+				return keys[i][0] < keys[j][0]
+			}
+			return keys[i][1] < keys[j][1]
+		})
+		for _, k := range keys {
+			sockets.add(withLabels(base, label{"proto", k[0]}, label{"state", k[1]}), float64(counts[k]))
+		}
+
+		resource, err := e.processService.GetProcessResource(ctx, pid)
+		if errors.Is(err, process.ErrProcessGone) {
+			continue
+		}
+		if err != nil {
+			slog.Error("Exporter.Write() -> resource unavailable", "pid", pid, "msg", err.Error())
+			continue
+		}
+		rss.add(base, float64(resource.ResidentSetSizeByte))
+		vsz.add(base, float64(resource.VirtualMemorySize))
+		if clockTick > 0 {
+			// the service rounds CPU time down to whole seconds, the ticks are exact
+			cpu.add(withLabels(base, label{"mode", "user"}), float64(resource.UserCPUTimeClockTick)/float64(clockTick))
+			cpu.add(withLabels(base, label{"mode", "system"}), float64(resource.SystemCPUTimeClockTick)/float64(clockTick))
+		}
+	}
+	processes.add(nil, float64(emitted))
+	duration.add(nil, time.Since(start).Seconds())
+
+	for _, f := range []*family{processes, sockets, listening, rss, vsz, cpu, duration} {
+		if err := f.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type label struct {
+	name, value string
+}
+
+func withLabels(base []label, extra ...label) []label {
+	out := make([]label, 0, len(base)+len(extra))
+	out = append(out, base...)
+	return append(out, extra...)
+}
+
+type sample struct {
+	labels []label
+	value  float64
+}
+
+type family struct {
+	name, kind, help string
+	samples          []sample
+}
+
+func newFamily(name, kind, help string) *family {
+	return &family{name: name, kind: kind, help: help}
+}
+
+func (f *family) add(labels []label, value float64) {
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+func (f *family) write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+	for _, s := range f.samples {
+		b.WriteString(f.name)
+		if len(s.labels) > 0 {
+			pairs := make([]string, 0, len(s.labels))
+			for _, l := range s.labels {
+				pairs = append(pairs, l.name+`="`+escapeLabelValue(l.value)+`"`)
+			}
+			b.WriteString("{" + strings.Join(pairs, ",") + "}")
+		}
+		b.WriteString(" " + strconv.FormatFloat(s.value, 'f', -1, 64) + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(v string) string {
+	return labelEscaper.Replace(v)
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"netps/internal/process"
+	"netps/internal/snapshot"
+	"netps/internal/socket"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// exiting is a snapshot where some processes exit between the socket walk and the name lookup
+type exiting struct {
+	*snapshot.Source
+	exited []int
+}
+
+func (e exiting) Name(ctx context.Context, pid int) (string, error) {
+	if slices.Contains(e.exited, pid) {
+		return "", fs.ErrNotExist
+	}
+	return e.Source.Name(ctx, pid)
+}
+
+// serve exposes processes through the exporter the way serve-metrics wires it
+func serve(t *testing.T, processes []snapshot.Process, exited ...int) *httptest.Server {
+	t.Helper()
+	source := snapshot.NewSource(snapshot.Snapshot{
+		Version:   snapshot.Version,
+		ClockTick: 100,
+		PageSize:  4096,
+		UpTime:    86400,
+		Processes: processes,
+	})
+	names := exiting{Source: source, exited: exited}
+	processService := process.NewProcessService(process.Config{
+		Process:   source,
+		Name:      names,
+		Detail:    source,
+		Clocktick: source,
+		PageSize:  source,
+		UpTime:    source,
+		Resource:  source,
+		User:      source,
+		Signal:    source,
+	})
+	server := httptest.NewServer(NewExporter(processService, socket.NewService(source)))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func scrape(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Content-Type"); got != ContentType {
+		t.Errorf("Content-Type %q, want %q", got, ContentType)
+	}
+	return string(body)
+}
+
+// the only value that changes from one scrape to the next
+var scrapeDuration = regexp.MustCompile(`(?m)^netps_scrape_duration_seconds [0-9.e-]+$`)
+
+func TestExposition(t *testing.T) {
+	reuse := func(inode uint64) socket.Socket {
+		return socket.Socket{Proto: socket.ProtoUDP, Addr: "0.0.0.0", Port: 53, State: socket.StateClose, Inode: inode}
+	}
+	server := serve(t, []snapshot.Process{
+		{
+			PID:  930,
+			Name: `dns"masq`,
+			Resource: &process.ProcessResource{
+				ResidentSetSizePage:    256,
+				VirtualMemorySize:      8 << 20,
+				UserCPUTimeClockTick:   150,
+				SystemCPUTimeClockTick: 25,
+			},
+			// one worker socket per CPU with SO_REUSEPORT, one series
+			Sockets: []socket.Socket{reuse(1), reuse(2), reuse(3),
+				{Proto: socket.ProtoTCP, Addr: "127.0.0.1", Port: 53, State: socket.StateListen, Inode: 4},
+				{Proto: socket.ProtoTCP, Addr: "127.0.0.1", Port: 53, RemoteAddr: "127.0.0.1", RemotePort: 40000, State: socket.StateEstablished, Inode: 5},
+			},
+		},
+		{
+			PID:         812,
+			Name:        "nginx",
+			ResourceErr: "open /proc/812/stat: permission denied",
+			Sockets:     []socket.Socket{{Proto: socket.ProtoTCP6, Addr: "::", Port: 443, State: socket.StateListen, Inode: 6}},
+		},
+	})
+
+	got := scrapeDuration.ReplaceAllString(scrape(t, server), "netps_scrape_duration_seconds 0")
+	want := `# HELP netps_processes Processes holding at least one socket.
+# TYPE netps_processes gauge
+netps_processes 2
+# HELP netps_process_sockets Sockets held by a process by protocol and state.
+# TYPE netps_process_sockets gauge
+netps_process_sockets{pid="812",name="nginx",proto="tcp6",state="LISTEN"} 1
+netps_process_sockets{pid="930",name="dns\"masq",proto="tcp",state="ESTABLISHED"} 1
+netps_process_sockets{pid="930",name="dns\"masq",proto="tcp",state="LISTEN"} 1
+netps_process_sockets{pid="930",name="dns\"masq",proto="udp",state="CLOSE"} 3
+# HELP netps_process_listen_port_info Ports a process listens on, or has bound for datagram protocols.
+# TYPE netps_process_listen_port_info gauge
+netps_process_listen_port_info{pid="812",name="nginx",proto="tcp6",addr="::",port="443"} 1
+netps_process_listen_port_info{pid="930",name="dns\"masq",proto="udp",addr="0.0.0.0",port="53"} 1
+netps_process_listen_port_info{pid="930",name="dns\"masq",proto="tcp",addr="127.0.0.1",port="53"} 1
+# HELP netps_process_resident_memory_bytes Resident set size of a process.
+# TYPE netps_process_resident_memory_bytes gauge
+netps_process_resident_memory_bytes{pid="930",name="dns\"masq"} 1048576
+# HELP netps_process_virtual_memory_bytes Virtual memory size of a process.
+# TYPE netps_process_virtual_memory_bytes gauge
+netps_process_virtual_memory_bytes{pid="930",name="dns\"masq"} 8388608
+# HELP netps_process_cpu_seconds_total CPU time consumed by a process by mode.
+# TYPE netps_process_cpu_seconds_total counter
+netps_process_cpu_seconds_total{pid="930",name="dns\"masq",mode="user"} 1.5
+netps_process_cpu_seconds_total{pid="930",name="dns\"masq",mode="system"} 0.25
+# HELP netps_scrape_duration_seconds Time taken to collect this scrape.
+# TYPE netps_scrape_duration_seconds gauge
+netps_scrape_duration_seconds 0
+`
+	if got != want {
+		t.Errorf("exposition:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestProcessesCountsEmittedSeries(t *testing.T) {
+	listener := func(inode uint64) []socket.Socket {
+		return []socket.Socket{{Proto: socket.ProtoTCP, Addr: "0.0.0.0", Port: 8000 + int(inode), State: socket.StateListen, Inode: inode}}
+	}
+	server := serve(t, []snapshot.Process{
+		{PID: 812, Name: "nginx", Sockets: listener(1)},
+		{PID: 813, Name: "nginx", Sockets: listener(2)},
+		{PID: 930, Name: "dnsmasq", Sockets: listener(3)},
+	}, 813)
+
+	body := scrape(t, server)
+	if !strings.Contains(body, "\nnetps_processes 2\n") {
+		t.Errorf("netps_processes counts the process that exited:\n%s", body)
+	}
+	if strings.Contains(body, `pid="813"`) {
+		t.Errorf("series for the process that exited:\n%s", body)
+	}
+}
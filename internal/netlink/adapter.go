@@ -0,0 +1,89 @@
+package netlink
+
+import (
+	procnet "netps/internal/procfs/net"
+	"netps/internal/procfs/root"
+	"netps/internal/socket"
+	"syscall"
+)
+
+// protocols answered by sock_diag, everything else is still read from /proc/net
+var diagProtocols = []struct {
+	family   uint8
+	protocol uint8
+	proto    string
+}{
+	{syscall.AF_INET, syscall.IPPROTO_TCP, socket.ProtoTCP},
+	{syscall.AF_INET6, syscall.IPPROTO_TCP, socket.ProtoTCP6},
+	{syscall.AF_INET, syscall.IPPROTO_UDP, socket.ProtoUDP},
+	{syscall.AF_INET6, syscall.IPPROTO_UDP, socket.ProtoUDP6},
+	{syscall.AF_INET, syscall.IPPROTO_UDPLITE, socket.ProtoUDPLite},
+	{syscall.AF_INET6, syscall.IPPROTO_UDPLITE, socket.ProtoUDPLite6},
+}
+
+// Table resolves socket inodes through NETLINK_SOCK_DIAG instead of parsing
+// /proc/net text tables. Raw, packet and sctp sockets are still read from
+// /proc/net, and the whole lookup falls back to /proc/net when netlink fails
+type Table struct {
+	fsys root.FS
+}
+
+// NewTable reads the tables sock_diag does not cover from the proc mount fsys
+func NewTable(fsys root.FS) *Table {
+	return &Table{fsys: fsys}
+}
+
+// Available reports whether a sock_diag socket can be opened on this host
+func Available() bool {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, netlinkSockDiag)
+	if err != nil {
+		return false
+	}
+	syscall.Close(fd)
+	return true
+}
+
+func (t *Table) InodeSockets() (map[uint64]socket.Socket, []error) {
+	sockets, covered, err := dumpAll()
+	if err != nil {
+		return procnet.ProcTable{FS: t.fsys}.InodeSockets()
+	}
+
+	rest, errs := procnet.ProcTable{FS: t.fsys, Skip: covered}.InodeSockets()
+	for inode, sock := range rest {
+		if _, exists := sockets[inode]; !exists {
+			sockets[inode] = sock
+		}
+	}
+	return sockets, errs
+}
+
+// Returns the sockets along with the protocols that were actually dumped
+func dumpAll() (map[uint64]socket.Socket, []string, error) {
+	sockets := make(map[uint64]socket.Socket)
+	covered := []string{}
+	for _, p := range diagProtocols {
+		m, err := dumpInet(p.family, p.protocol, p.proto)
+		if err != nil {
+			// udplite needs its own diag module, missing it is not a netlink failure
+			if p.protocol == syscall.IPPROTO_UDPLITE {
+				continue
+			}
+			return nil, nil, err
+		}
+		for inode, sock := range m {
+			sockets[inode] = sock
+		}
+		covered = append(covered, p.proto)
+	}
+
+	unixSockets, err := dumpUnix()
+	if err != nil {
+		return nil, nil, err
+	}
+	for inode, sock := range unixSockets {
+		sockets[inode] = sock
+	}
+	covered = append(covered, socket.ProtoUnix)
+	return sockets, covered, nil
+}
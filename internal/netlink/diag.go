@@ -0,0 +1,94 @@
+package netlink
+
+import (
+	"encoding/binary"
+	"errors"
+	"syscall"
+)
+
+const (
+	netlinkSockDiag  = 4  // NETLINK_SOCK_DIAG
+	sockDiagByFamily = 20 // SOCK_DIAG_BY_FAMILY
+	nlmsgHeaderLen   = 16
+	rtattrHeaderLen  = 4
+	receiveBufSize   = 32 * 1024
+)
+
+// Sends a single SOCK_DIAG_BY_FAMILY dump request and collects the payload
+// of every answer until NLMSG_DONE
+func dump(request []byte) ([][]byte, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC, netlinkSockDiag)
+	if err != nil {
+		return nil, err
+	}
+	defer syscall.Close(fd)
+
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+
+	msg := make([]byte, nlmsgHeaderLen+len(request))
+	binary.NativeEndian.PutUint32(msg[0:4], uint32(len(msg)))
+	binary.NativeEndian.PutUint16(msg[4:6], sockDiagByFamily)
+	binary.NativeEndian.PutUint16(msg[6:8], syscall.NLM_F_REQUEST|syscall.NLM_F_DUMP)
+	binary.NativeEndian.PutUint32(msg[8:12], 1) // seq
+	copy(msg[nlmsgHeaderLen:], request)
+
+	if err := syscall.Sendto(fd, msg, 0, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK}); err != nil {
+		return nil, err
+	}
+
+	out := [][]byte{}
+	buf := make([]byte, receiveBufSize)
+	for {
+		n, _, err := syscall.Recvfrom(fd, buf, 0)
+		if errors.Is(err, syscall.EINTR) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		msgs, err := syscall.ParseNetlinkMessage(buf[:n])
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range msgs {
+			switch m.Header.Type {
+			case syscall.NLMSG_DONE:
+				return out, nil
+			case syscall.NLMSG_ERROR:
+				if len(m.Data) < 4 {
+					return nil, errors.New("netlink: truncated error message")
+				}
+				errno := -int32(binary.NativeEndian.Uint32(m.Data[0:4]))
+				if errno == 0 {
+					continue // ack
+				}
+				return nil, syscall.Errno(errno)
+			}
+			// m.Data points into buf which is reused on the next read
+			out = append(out, append([]byte(nil), m.Data...))
+		}
+	}
+}
+
+// Walks the rtattr list trailing a diag message
+func parseAttributes(b []byte) map[uint16][]byte {
+	attrs := map[uint16][]byte{}
+	for len(b) >= rtattrHeaderLen {
+		l := int(binary.NativeEndian.Uint16(b[0:2]))
+		t := binary.NativeEndian.Uint16(b[2:4])
+		if l < rtattrHeaderLen || l > len(b) {
+			break
+		}
+		attrs[t] = b[rtattrHeaderLen:l]
+
+		aligned := (l + 3) &^ 3
+		if aligned > len(b) {
+			break
+		}
+		b = b[aligned:]
+	}
+	return attrs
+}
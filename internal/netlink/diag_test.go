@@ -0,0 +1,51 @@
+package netlink
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+// attr encodes one rtattr, padded to 4 bytes like the kernel does
+func attr(t uint16, payload []byte) []byte {
+	l := rtattrHeaderLen + len(payload)
+	b := make([]byte, (l+3)&^3)
+	binary.NativeEndian.PutUint16(b[0:2], uint16(l))
+	binary.NativeEndian.PutUint16(b[2:4], t)
+	copy(b[rtattrHeaderLen:], payload)
+	return b
+}
+
+func join(parts ...[]byte) []byte {
+	out := []byte{}
+	for _, p := range parts {
+		out = append(out, p...)
+	}
+	return out
+}
+
+func TestParseAttributes(t *testing.T) {
+	name := attr(0, []byte("/run/x.sock\x00")) // already aligned
+	uid := attr(7, []byte{0xe8, 0x03, 0, 0})
+	odd := attr(3, []byte{1, 2, 3, 4, 5}) // 9 bytes, 3 of padding
+
+	cases := []struct {
+		name string
+		b    []byte
+		want map[uint16][]byte
+	}{
+		{"empty", nil, map[uint16][]byte{}},
+		{"shorter than a header", []byte{8, 0}, map[uint16][]byte{}},
+		{"two attributes", join(name, uid), map[uint16][]byte{0: []byte("/run/x.sock\x00"), 7: {0xe8, 0x03, 0, 0}}},
+		{"padding skipped", join(odd, uid), map[uint16][]byte{3: {1, 2, 3, 4, 5}, 7: {0xe8, 0x03, 0, 0}}},
+		{"last attribute without padding", join(uid, odd[:9]), map[uint16][]byte{7: {0xe8, 0x03, 0, 0}, 3: {1, 2, 3, 4, 5}}},
+		{"length past the buffer", join(uid, odd[:7]), map[uint16][]byte{7: {0xe8, 0x03, 0, 0}}},
+		{"length shorter than the header", join(uid, []byte{2, 0, 9, 0}, odd), map[uint16][]byte{7: {0xe8, 0x03, 0, 0}}},
+		{"empty payload", attr(9, nil), map[uint16][]byte{9: {}}},
+	}
+	for _, c := range cases {
+		if got := parseAttributes(c.b); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: parseAttributes() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package netlink
+
+import (
+	"encoding/binary"
+	"net"
+	"netps/internal/socket"
+	"syscall"
+	"time"
+)
+
+const (
+	inetDiagInfo   = 2 // INET_DIAG_INFO, carries struct tcp_info
+	inetDiagReqLen = 56
+	inetDiagMsgLen = 72
+	allStates      = 0xffffffff
+)
+
+// TCP states as numbered by the kernel, shared by inet_diag for every protocol
+var tcpStates = map[uint8]socket.SocketState{
+	1:  socket.StateEstablished,
+	2:  socket.StateSynSent,
+	3:  socket.StateSynRecv,
+	4:  socket.StateFinWait1,
+	5:  socket.StateFinWait2,
+	6:  socket.StateTimeWait1,
+	7:  socket.StateClose,
+	8:  socket.StateCloseWait,
+	9:  socket.StateLastAck,
+	10: socket.StateListen,
+	11: socket.StateClosing,
+	12: socket.StateNewSynRecv,
+}
+
+// Dumps every socket of one address family and protocol through inet_diag
+//
+// Request (struct inet_diag_req_v2):
+// family u8, protocol u8, ext u8, pad u8, states u32, struct inet_diag_sockid (48 bytes)
+func dumpInet(family uint8, protocol uint8, proto string) (map[uint64]socket.Socket, error) {
+	req := make([]byte, inetDiagReqLen)
+	req[0] = family
+	req[1] = protocol
+	if protocol == syscall.IPPROTO_TCP {
+		req[2] = 1 << (inetDiagInfo - 1)
+	}
+	binary.NativeEndian.PutUint32(req[4:8], allStates)
+
+	msgs, err := dump(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := make(map[uint64]socket.Socket)
+	for _, m := range msgs {
+		sock, ok := parseInetDiagMsg(m, proto)
+		if !ok {
+			continue
+		}
+		sockets[sock.Inode] = sock
+	}
+	return sockets, nil
+}
+
+// Response (struct inet_diag_msg):
+// family u8, state u8, timer u8, retrans u8,
+// sport be16, dport be16, src [16]byte, dst [16]byte, if u32, cookie [2]u32,
+// expires u32, rqueue u32, wqueue u32, uid u32, inode u32, followed by rtattrs
+func parseInetDiagMsg(b []byte, proto string) (socket.Socket, bool) {
+	if len(b) < inetDiagMsgLen {
+		return socket.Socket{}, false
+	}
+
+	addrLen := net.IPv4len
+	if b[0] == syscall.AF_INET6 {
+		addrLen = net.IPv6len
+	}
+
+	state, ok := tcpStates[b[1]]
+	if !ok {
+		state = socket.StateUnknown
+	}
+
+	sock := socket.Socket{
+		Proto:      proto,
+		Addr:       net.IP(append([]byte(nil), b[8:8+addrLen]...)).String(),
+		Port:       int(binary.BigEndian.Uint16(b[4:6])),
+		RemoteAddr: net.IP(append([]byte(nil), b[24:24+addrLen]...)).String(),
+		RemotePort: int(binary.BigEndian.Uint16(b[6:8])),
+		State:      state,
+		UID:        int(binary.NativeEndian.Uint32(b[64:68])),
+		Inode:      uint64(binary.NativeEndian.Uint32(b[68:72])),
+	}
+
+	attrs := parseAttributes(b[inetDiagMsgLen:])
+	if info, ok := attrs[inetDiagInfo]; ok {
+		sock.TCPInfo = parseTCPInfo(info)
+	}
+	return sock, true
+}
+
+// Offsets into struct tcp_info (linux/tcp.h), older kernels send a shorter struct
+func parseTCPInfo(b []byte) *socket.TCPInfo {
+	if len(b) < 104 {
+		return nil
+	}
+	info := &socket.TCPInfo{
+		RTT:              time.Duration(binary.NativeEndian.Uint32(b[68:72])) * time.Microsecond,
+		RTTVar:           time.Duration(binary.NativeEndian.Uint32(b[72:76])) * time.Microsecond,
+		CongestionWindow: binary.NativeEndian.Uint32(b[80:84]),
+		Retransmits:      binary.NativeEndian.Uint32(b[100:104]),
+	}
+	if len(b) >= 136 {
+		info.BytesAcked = binary.NativeEndian.Uint64(b[120:128])
+		info.BytesReceived = binary.NativeEndian.Uint64(b[128:136])
+	}
+	return info
+}
@@ -0,0 +1,105 @@
+package netlink
+
+import (
+	"encoding/binary"
+	"net"
+	"netps/internal/socket"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// inetMsg encodes a struct inet_diag_msg, addresses are written the way the kernel
+// does: an IPv4 address takes the first 4 of the 16 bytes
+func inetMsg(family, state uint8, src net.IP, sport uint16, dst net.IP, dport uint16, uid, inode uint32) []byte {
+	b := make([]byte, inetDiagMsgLen)
+	b[0] = family
+	b[1] = state
+	binary.BigEndian.PutUint16(b[4:6], sport)
+	binary.BigEndian.PutUint16(b[6:8], dport)
+	if family == syscall.AF_INET {
+		copy(b[8:24], src.To4())
+		copy(b[24:40], dst.To4())
+	} else {
+		copy(b[8:24], src.To16())
+		copy(b[24:40], dst.To16())
+	}
+	binary.NativeEndian.PutUint32(b[64:68], uid)
+	binary.NativeEndian.PutUint32(b[68:72], inode)
+	return b
+}
+
+// tcpInfo encodes a struct tcp_info of the given length
+func tcpInfo(length int) []byte {
+	b := make([]byte, length)
+	binary.NativeEndian.PutUint32(b[68:72], 1500) // rtt µs
+	binary.NativeEndian.PutUint32(b[72:76], 250)  // rttvar µs
+	binary.NativeEndian.PutUint32(b[80:84], 10)   // snd_cwnd
+	binary.NativeEndian.PutUint32(b[100:104], 3)  // total_retrans
+	if length >= 136 {
+		binary.NativeEndian.PutUint64(b[120:128], 1<<20) // bytes_acked
+		binary.NativeEndian.PutUint64(b[128:136], 4096)  // bytes_received
+	}
+	return b
+}
+
+func TestParseInetDiagMsg(t *testing.T) {
+	v4 := inetMsg(syscall.AF_INET, 1, net.ParseIP("10.0.0.5"), 443, net.ParseIP("10.0.0.9"), 51202, 33, 4242)
+	v6 := inetMsg(syscall.AF_INET6, 10, net.ParseIP("fe80::1"), 8080, net.IPv6unspecified, 0, 0, 77)
+	mapped := inetMsg(syscall.AF_INET6, 1, net.ParseIP("::ffff:192.0.2.1"), 25, net.ParseIP("::ffff:192.0.2.2"), 40000, 0, 78)
+	// the kernel leaves garbage after an IPv4 address on some paths, it must not leak into it
+	dirty := inetMsg(syscall.AF_INET, 7, net.ParseIP("127.0.0.53"), 53, net.IPv4zero, 0, 101, 90)
+	copy(dirty[12:24], []byte{0xde, 0xad, 0xbe, 0xef, 1, 2, 3, 4, 5, 6, 7, 8})
+	unknown := inetMsg(syscall.AF_INET, 42, net.IPv4zero, 1, net.IPv4zero, 0, 0, 91)
+
+	cases := []struct {
+		name  string
+		b     []byte
+		proto string
+		want  socket.Socket
+		ok    bool
+	}{
+		{"empty", nil, socket.ProtoTCP, socket.Socket{}, false},
+		{"one byte short", v4[:inetDiagMsgLen-1], socket.ProtoTCP, socket.Socket{}, false},
+		{"ipv4", v4, socket.ProtoTCP, socket.Socket{Proto: socket.ProtoTCP, Addr: "10.0.0.5", Port: 443, RemoteAddr: "10.0.0.9", RemotePort: 51202, State: socket.StateEstablished, UID: 33, Inode: 4242}, true},
+		{"ipv6", v6, socket.ProtoTCP6, socket.Socket{Proto: socket.ProtoTCP6, Addr: "fe80::1", Port: 8080, RemoteAddr: "::", State: socket.StateListen, Inode: 77}, true},
+		{"ipv4 mapped ipv6", mapped, socket.ProtoTCP6, socket.Socket{Proto: socket.ProtoTCP6, Addr: "192.0.2.1", Port: 25, RemoteAddr: "192.0.2.2", RemotePort: 40000, State: socket.StateEstablished, Inode: 78}, true},
+		{"ipv4 ignores the rest of the address", dirty, socket.ProtoUDP, socket.Socket{Proto: socket.ProtoUDP, Addr: "127.0.0.53", Port: 53, RemoteAddr: "0.0.0.0", State: socket.StateClose, UID: 101, Inode: 90}, true},
+		{"unknown state", unknown, socket.ProtoTCP, socket.Socket{Proto: socket.ProtoTCP, Addr: "0.0.0.0", Port: 1, RemoteAddr: "0.0.0.0", State: socket.StateUnknown, Inode: 91}, true},
+		{"tcp info", join(v4, attr(inetDiagInfo, tcpInfo(104))), socket.ProtoTCP, socket.Socket{Proto: socket.ProtoTCP, Addr: "10.0.0.5", Port: 443, RemoteAddr: "10.0.0.9", RemotePort: 51202, State: socket.StateEstablished, UID: 33, Inode: 4242,
+			TCPInfo: &socket.TCPInfo{RTT: 1500 * time.Microsecond, RTTVar: 250 * time.Microsecond, CongestionWindow: 10, Retransmits: 3}}, true},
+		{"truncated tcp info attribute", join(v4, attr(inetDiagInfo, tcpInfo(104))[:60]), socket.ProtoTCP, socket.Socket{Proto: socket.ProtoTCP, Addr: "10.0.0.5", Port: 443, RemoteAddr: "10.0.0.9", RemotePort: 51202, State: socket.StateEstablished, UID: 33, Inode: 4242}, true},
+		{"other attributes", join(v6, attr(1, []byte{1, 2, 3, 4})), socket.ProtoTCP6, socket.Socket{Proto: socket.ProtoTCP6, Addr: "fe80::1", Port: 8080, RemoteAddr: "::", State: socket.StateListen, Inode: 77}, true},
+	}
+	for _, c := range cases {
+		got, ok := parseInetDiagMsg(c.b, c.proto)
+		if ok != c.ok || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: parseInetDiagMsg() = %+v, %v, want %+v, %v", c.name, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestParseTCPInfo(t *testing.T) {
+	short := &socket.TCPInfo{RTT: 1500 * time.Microsecond, RTTVar: 250 * time.Microsecond, CongestionWindow: 10, Retransmits: 3}
+	full := *short
+	full.BytesAcked, full.BytesReceived = 1<<20, 4096
+
+	cases := []struct {
+		name string
+		b    []byte
+		want *socket.TCPInfo
+	}{
+		{"empty", nil, nil},
+		{"shorter than the oldest struct", tcpInfo(104)[:103], nil},
+		{"without byte counters", tcpInfo(104), short},
+		{"byte counters cut off", tcpInfo(136)[:135], short},
+		{"with byte counters", tcpInfo(136), &full},
+		{"newer kernel", append(tcpInfo(136), make([]byte, 96)...), &full},
+	}
+	for _, c := range cases {
+		if got := parseTCPInfo(c.b); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: parseTCPInfo() = %+v, want %+v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package netlink
+
+import (
+	"encoding/binary"
+	"netps/internal/socket"
+	"strings"
+	"syscall"
+)
+
+const (
+	unixDiagName   = 0 // UNIX_DIAG_NAME
+	unixDiagUID    = 7 // UNIX_DIAG_UID, kernel 5.3+
+	unixShowName   = 0x01
+	unixShowUID    = 0x40
+	unixDiagReqLen = 24
+	unixDiagMsgLen = 16
+)
+
+// Dumps every unix domain socket through unix_diag
+//
+// Request (struct unix_diag_req):
+// family u8, protocol u8, pad u16, states u32, ino u32, show u32, cookie [2]u32
+func dumpUnix() (map[uint64]socket.Socket, error) {
+	req := make([]byte, unixDiagReqLen)
+	req[0] = syscall.AF_UNIX
+	binary.NativeEndian.PutUint32(req[4:8], allStates)
+	binary.NativeEndian.PutUint32(req[12:16], unixShowName|unixShowUID)
+
+	msgs, err := dump(req)
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := make(map[uint64]socket.Socket)
+	for _, m := range msgs {
+		sock, ok := parseUnixDiagMsg(m)
+		if !ok {
+			continue
+		}
+		sockets[sock.Inode] = sock
+	}
+	return sockets, nil
+}
+
+// Response (struct unix_diag_msg):
+// family u8, type u8, state u8, pad u8, ino u32, cookie [2]u32, followed by rtattrs
+func parseUnixDiagMsg(b []byte) (socket.Socket, bool) {
+	if len(b) < unixDiagMsgLen {
+		return socket.Socket{}, false
+	}
+
+	sock := socket.Socket{
+		Proto: socket.ProtoUnix,
+		State: unixState(b[2]),
+		Type:  unixType(b[1]),
+		UID:   -1,
+		Inode: uint64(binary.NativeEndian.Uint32(b[4:8])),
+	}
+
+	attrs := parseAttributes(b[unixDiagMsgLen:])
+	if name, ok := attrs[unixDiagName]; ok && len(name) > 0 {
+		// abstract names start with a NUL byte, /proc/net/unix shows them with "@"
+		if name[0] == 0 {
+			sock.Path = "@" + string(name[1:])
+		} else {
+			sock.Path = strings.TrimRight(string(name), "\x00")
+		}
+	}
+	if uid, ok := attrs[unixDiagUID]; ok && len(uid) >= 4 {
+		sock.UID = int(binary.NativeEndian.Uint32(uid[0:4]))
+	}
+	return sock, true
+}
+
+// unix_diag reports TCP state numbers, matching what /proc/net/unix derives from SS_* and __SO_ACCEPTCON
+func unixState(state uint8) socket.SocketState {
+	switch state {
+	case 10:
+		return socket.StateListen
+	case 1:
+		return socket.StateEstablished
+	case 2:
+		return socket.StateConnecting
+	case 7:
+		return socket.StateUnconnected
+	default:
+		return socket.StateUnknown
+	}
+}
+
+func unixType(t uint8) string {
+	switch t {
+	case syscall.SOCK_STREAM:
+		return "stream"
+	case syscall.SOCK_DGRAM:
+		return "dgram"
+	case syscall.SOCK_SEQPACKET:
+		return "seqpacket"
+	default:
+		return "unknown"
+	}
+}
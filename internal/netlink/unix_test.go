@@ -0,0 +1,48 @@
+package netlink
+
+import (
+	"encoding/binary"
+	"netps/internal/socket"
+	"reflect"
+	"syscall"
+	"testing"
+)
+
+// unixMsg encodes a struct unix_diag_msg
+func unixMsg(typ, state uint8, inode uint32) []byte {
+	b := make([]byte, unixDiagMsgLen)
+	b[0] = syscall.AF_UNIX
+	b[1] = typ
+	b[2] = state
+	binary.NativeEndian.PutUint32(b[4:8], inode)
+	return b
+}
+
+func TestParseUnixDiagMsg(t *testing.T) {
+	listener := unixMsg(syscall.SOCK_STREAM, 10, 1001)
+	uid := attr(unixDiagUID, []byte{0xe8, 0x03, 0, 0})
+
+	cases := []struct {
+		name string
+		b    []byte
+		want socket.Socket
+		ok   bool
+	}{
+		{"empty", nil, socket.Socket{}, false},
+		{"one byte short", listener[:unixDiagMsgLen-1], socket.Socket{}, false},
+		{"unnamed without uid", unixMsg(syscall.SOCK_DGRAM, 7, 1002), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateUnconnected, Type: "dgram", UID: -1, Inode: 1002}, true},
+		{"path", join(listener, attr(unixDiagName, []byte("/run/docker.sock")), uid), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateListen, Type: "stream", Path: "/run/docker.sock", UID: 1000, Inode: 1001}, true},
+		{"path with trailing NUL", join(listener, attr(unixDiagName, []byte("/tmp/.X11-unix/X0\x00"))), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateListen, Type: "stream", Path: "/tmp/.X11-unix/X0", UID: -1, Inode: 1001}, true},
+		{"abstract", join(unixMsg(syscall.SOCK_SEQPACKET, 1, 1003), attr(unixDiagName, []byte("\x00/containerd-shim/x.sock"))), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateEstablished, Type: "seqpacket", Path: "@/containerd-shim/x.sock", UID: -1, Inode: 1003}, true},
+		{"empty name", join(listener, attr(unixDiagName, nil)), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateListen, Type: "stream", UID: -1, Inode: 1001}, true},
+		{"uid cut short", join(listener, attr(unixDiagUID, []byte{0xe8, 0x03})), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateListen, Type: "stream", UID: -1, Inode: 1001}, true},
+		{"truncated name attribute", join(listener, uid, attr(unixDiagName, []byte("/run/docker.sock"))[:10]), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateListen, Type: "stream", UID: 1000, Inode: 1001}, true},
+		{"unknown type and state", unixMsg(9, 3, 1004), socket.Socket{Proto: socket.ProtoUnix, State: socket.StateUnknown, Type: "unknown", UID: -1, Inode: 1004}, true},
+	}
+	for _, c := range cases {
+		got, ok := parseUnixDiagMsg(c.b)
+		if ok != c.ok || !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: parseUnixDiagMsg() = %+v, %v, want %+v, %v", c.name, got, ok, c.want, c.ok)
+		}
+	}
+}
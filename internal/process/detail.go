@@ -1,8 +1,9 @@
 package process
 
 type ProcessDetail struct {
-	ExecPath   string
-	Command    string
-	PPID       int
-	ParentName string
+	Name       string `json:"name"`
+	ExecPath   string `json:"exec_path"`
+	Command    string `json:"command"`
+	PPID       int    `json:"ppid"`
+	ParentName string `json:"parent_name"`
 }
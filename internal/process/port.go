@@ -1,11 +1,18 @@
 package process
 
-import "context"
+import (
+	"context"
+	"syscall"
+)
 
 type SummarySource interface {
 	ListRunnings(ctx context.Context) ([]ProcessSummary, error)
 }
 
+type NameSource interface {
+	Name(ctx context.Context, pid int) (string, error)
+}
+
 type DetailSource interface {
 	Detail(ctx context.Context, pid int) (ProcessDetail, error)
 }
@@ -29,3 +36,7 @@ type PageSizeSource interface {
 type UserSource interface {
 	User(ctx context.Context, pid int) (ProcessUser, error)
 }
+
+type SignalSink interface {
+	Signal(ctx context.Context, pid int, sig syscall.Signal) error
+}
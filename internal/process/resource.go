@@ -2,15 +2,22 @@ package process
 
 import "time"
 
+// Only the raw fields are serialized, the others are derived by the service
 type ProcessResource struct {
-	ResidentSetSizePage    int64 //pages
-	ResidentSetSizeByte    int64
-	VirtualMemorySize      uint64 // bytes
-	StartTimeTick          uint64
-	StartTimeSec           time.Duration
-	ElapsedTimeSec         time.Duration
-	UserCPUTimeSecond      time.Duration
-	UserCPUTimeClockTick   uint64
-	SystemCPUTimeSecond    time.Duration
-	SystemCPUTimeClockTick uint64
+	ResidentSetSizePage    int64         `json:"rss_pages"` //pages
+	ResidentSetSizeByte    int64         `json:"-"`
+	VirtualMemorySize      uint64        `json:"vsz_bytes"` // bytes
+	StartTimeTick          uint64        `json:"start_time_ticks"`
+	StartTimeSec           time.Duration `json:"-"`
+	ElapsedTimeSec         time.Duration `json:"-"`
+	UserCPUTimeSecond      time.Duration `json:"-"`
+	UserCPUTimeClockTick   uint64        `json:"user_cpu_ticks"`
+	SystemCPUTimeSecond    time.Duration `json:"-"`
+	SystemCPUTimeClockTick uint64        `json:"system_cpu_ticks"`
+	State                  string        `json:"state"` // as named by /proc/[pid]/stat, e.g. "Sleeping" or "Zombie"
+}
+
+// Exited reports whether the process has terminated and is only waiting to be reaped
+func (r ProcessResource) Exited() bool {
+	return r.State == "Zombie" || r.State == "Dead"
 }
@@ -2,27 +2,37 @@ package process
 
 import (
 	"context"
+	"errors"
+	"io/fs"
+	"syscall"
 	"time"
 )
 
+// ErrProcessGone is returned when the process exited while it was being looked at
+var ErrProcessGone = errors.New("process has exited")
+
 type Service struct {
 	process   SummarySource
+	name      NameSource
 	detail    DetailSource
 	clocktick ClockTickSource
 	pageSize  PageSizeSource
 	upTime    UpTimeSource
 	resource  ResourceSource
 	user      UserSource
+	signal    SignalSink
 }
 
 type Config struct {
 	Process   SummarySource
+	Name      NameSource
 	Detail    DetailSource
 	Clocktick ClockTickSource
 	PageSize  PageSizeSource
 	UpTime    UpTimeSource
 	Resource  ResourceSource
 	User      UserSource
+	Signal    SignalSink
 }
 
 func NewProcessService(
@@ -30,12 +40,14 @@ func NewProcessService(
 ) *Service {
 	return &Service{
 		process:   cfg.Process,
+		name:      cfg.Name,
 		detail:    cfg.Detail,
 		clocktick: cfg.Clocktick,
 		pageSize:  cfg.PageSize,
 		upTime:    cfg.UpTime,
 		resource:  cfg.Resource,
 		user:      cfg.User,
+		signal:    cfg.Signal,
 	}
 }
 
@@ -54,10 +66,14 @@ func (s *Service) GetRunningSummaries(ctx context.Context) ([]ProcessSummary, er
 	return out, nil
 }
 
+func (s *Service) GetProcessName(ctx context.Context, pid int) (string, error) {
+	return s.name.Name(ctx, pid)
+}
+
 func (s *Service) GetProcessDetail(ctx context.Context, pid int) (ProcessDetail, error) {
 	processDetail, err := s.detail.Detail(ctx, pid)
 	if err != nil {
-		return ProcessDetail{}, nil
+		return ProcessDetail{}, err
 	}
 
 	return processDetail, nil
@@ -83,9 +99,15 @@ func (s *Service) GetPageSize(ctx context.Context) (int64, error) {
 
 func (s *Service) GetProcessResource(ctx context.Context, pid int) (ProcessResource, error) {
 	processResource, err := s.resource.Resource(ctx, pid)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ESRCH) {
+		return ProcessResource{}, ErrProcessGone
+	}
 	if err != nil {
 		return ProcessResource{}, err
 	}
+	if processResource.Exited() {
+		return ProcessResource{}, ErrProcessGone
+	}
 	sysClockTick, err := s.clocktick.ClockTick(ctx)
 	if err != nil {
 		return ProcessResource{}, err
@@ -94,7 +116,7 @@ func (s *Service) GetProcessResource(ctx context.Context, pid int) (ProcessResou
 	startTimeSec := (processResource.StartTimeTick) / uint64(sysClockTick)
 	upTime, err := s.upTime.UpTime(ctx)
 	if err != nil {
-		return ProcessResource{}, nil
+		return ProcessResource{}, err
 	}
 	elapsedTime := upTime - float64(startTimeSec)
 	uCpuTimeSec := (processResource.UserCPUTimeClockTick / uint64(sysClockTick))
@@ -117,7 +139,15 @@ func (s *Service) GetProcessResource(ctx context.Context, pid int) (ProcessResou
 func (s *Service) GetUser(ctx context.Context, pid int) (ProcessUser, error) {
 	user, err := s.user.User(ctx, pid)
 	if err != nil {
-		return ProcessUser{}, nil
+		return ProcessUser{}, err
 	}
 	return user, nil
 }
+
+func (s *Service) SendSignal(ctx context.Context, pid int, sig syscall.Signal) error {
+	err := s.signal.Signal(ctx, pid, sig)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package process
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+type Signal struct {
+	Name        string
+	Number      syscall.Signal
+	Description string
+	Destructive bool // default action terminates the process, requires confirmation
+}
+
+// glibc reserves the first two kernel real-time signals (32, 33) for threading,
+// so the user visible SIGRTMIN starts at 34, same as `kill -l`
+const (
+	SignalRTMin = syscall.Signal(34)
+	SignalRTMax = syscall.Signal(64)
+)
+
+var standardSignals = []Signal{
+	{Name: "SIGHUP", Number: syscall.SIGHUP, Description: "reload / restart hint", Destructive: true},
+	{Name: "SIGINT", Number: syscall.SIGINT, Description: "interrupt", Destructive: true},
+	{Name: "SIGQUIT", Number: syscall.SIGQUIT, Description: "quit with core dump", Destructive: true},
+	{Name: "SIGILL", Number: syscall.SIGILL, Description: "illegal instruction", Destructive: true},
+	{Name: "SIGTRAP", Number: syscall.SIGTRAP, Description: "trace / breakpoint trap", Destructive: true},
+	{Name: "SIGABRT", Number: syscall.SIGABRT, Description: "abort with core dump", Destructive: true},
+	{Name: "SIGBUS", Number: syscall.SIGBUS, Description: "bus error", Destructive: true},
+	{Name: "SIGFPE", Number: syscall.SIGFPE, Description: "floating point exception", Destructive: true},
+	{Name: "SIGKILL", Number: syscall.SIGKILL, Description: "immediate termination", Destructive: true},
+	{Name: "SIGUSR1", Number: syscall.SIGUSR1, Description: "user defined 1", Destructive: true},
+	{Name: "SIGSEGV", Number: syscall.SIGSEGV, Description: "segmentation fault", Destructive: true},
+	{Name: "SIGUSR2", Number: syscall.SIGUSR2, Description: "user defined 2", Destructive: true},
+	{Name: "SIGPIPE", Number: syscall.SIGPIPE, Description: "broken pipe", Destructive: true},
+	{Name: "SIGALRM", Number: syscall.SIGALRM, Description: "timer alarm", Destructive: true},
+	{Name: "SIGTERM", Number: syscall.SIGTERM, Description: "graceful termination", Destructive: true},
+	{Name: "SIGCHLD", Number: syscall.SIGCHLD, Description: "child stopped or exited"},
+	{Name: "SIGCONT", Number: syscall.SIGCONT, Description: "continue if stopped"},
+	{Name: "SIGSTOP", Number: syscall.SIGSTOP, Description: "stop (cannot be caught)"},
+	{Name: "SIGTSTP", Number: syscall.SIGTSTP, Description: "terminal stop"},
+	{Name: "SIGTTIN", Number: syscall.SIGTTIN, Description: "background read from tty"},
+	{Name: "SIGTTOU", Number: syscall.SIGTTOU, Description: "background write to tty"},
+	{Name: "SIGURG", Number: syscall.SIGURG, Description: "urgent socket data"},
+	{Name: "SIGXCPU", Number: syscall.SIGXCPU, Description: "cpu time limit exceeded", Destructive: true},
+	{Name: "SIGXFSZ", Number: syscall.SIGXFSZ, Description: "file size limit exceeded", Destructive: true},
+	{Name: "SIGVTALRM", Number: syscall.SIGVTALRM, Description: "virtual timer alarm", Destructive: true},
+	{Name: "SIGPROF", Number: syscall.SIGPROF, Description: "profiling timer alarm", Destructive: true},
+	{Name: "SIGWINCH", Number: syscall.SIGWINCH, Description: "window resize"},
+	{Name: "SIGIO", Number: syscall.SIGIO, Description: "i/o possible", Destructive: true},
+	{Name: "SIGPWR", Number: syscall.SIGPWR, Description: "power failure", Destructive: true},
+	{Name: "SIGSYS", Number: syscall.SIGSYS, Description: "bad system call", Destructive: true},
+}
+
+var signalAliases = map[string]string{
+	"SIGIOT":  "SIGABRT",
+	"SIGPOLL": "SIGIO",
+	"SIGCLD":  "SIGCHLD",
+}
+
+// Signals is the full catalogue shown to the user: standard signals
+// followed by the real-time range, ordered by number
+var Signals = slices.Concat(standardSignals, realTimeSignals())
+
+func realTimeSignals() []Signal {
+	out := []Signal{}
+	half := (SignalRTMax - SignalRTMin) / 2
+	for n := SignalRTMin; n <= SignalRTMax; n++ {
+		var name string
+		switch {
+		case n == SignalRTMin:
+			name = "SIGRTMIN"
+		case n == SignalRTMax:
+			name = "SIGRTMAX"
+		case n-SignalRTMin <= half:
+			name = fmt.Sprintf("SIGRTMIN+%d", n-SignalRTMin)
+		default:
+			name = fmt.Sprintf("SIGRTMAX-%d", SignalRTMax-n)
+		}
+		out = append(out, Signal{Name: name, Number: n, Description: "real-time signal", Destructive: true})
+	}
+	return out
+}
+
+// LookupSignal finds a signal in the catalogue by its number
+func LookupSignal(number syscall.Signal) (Signal, bool) {
+	for _, s := range Signals {
+		if s.Number == number {
+			return s, true
+		}
+	}
+	return Signal{}, false
+}
+
+// ParseSignal accepts a signal number ("9"), a name with or without
+// the SIG prefix in any case ("KILL", "sigkill") or a real-time offset ("RTMIN+3")
+func ParseSignal(raw string) (Signal, error) {
+	s := strings.ToUpper(strings.TrimSpace(raw))
+	if s == "" {
+		return Signal{}, fmt.Errorf("empty signal")
+	}
+
+	if n, err := strconv.Atoi(s); err == nil {
+		sig, ok := LookupSignal(syscall.Signal(n))
+		if !ok {
+			return Signal{}, fmt.Errorf("unknown signal number %d", n)
+		}
+		return sig, nil
+	}
+
+	if !strings.HasPrefix(s, "SIG") {
+		s = "SIG" + s
+	}
+	if alias, ok := signalAliases[s]; ok {
+		s = alias
+	}
+	for _, sig := range Signals {
+		if sig.Name == s {
+			return sig, nil
+		}
+	}
+	return Signal{}, fmt.Errorf("unknown signal %q", raw)
+}
+
+// DeliveryFailureReason explains why kill(2) failed in the user's terms
+func DeliveryFailureReason(err error) string {
+	switch {
+	case errors.Is(err, syscall.EPERM):
+		return "permission denied"
+	case errors.Is(err, syscall.ESRCH):
+		return "no longer exists"
+	default:
+		return err.Error()
+	}
+}
@@ -1,18 +1,27 @@
 package process
 
 import (
+	"cmp"
 	"netps/internal/socket"
+	"slices"
 	"strconv"
 	"strings"
 )
 
+// Appended to ports whose socket is held by more than one process
+const SharedMarker = "*"
+
 type ProcessSummary struct {
-	PID          int
-	Name         string
-	LSocketCount int
-	ESocketCount int
-	CSocketCount int
-	LPortsText   string
+	PID          int      `json:"pid"`
+	Name         string   `json:"name"`
+	LSocketCount int      `json:"listen_count"`
+	ESocketCount int      `json:"established_count"`
+	CSocketCount int      `json:"close_count"`
+	USocketCount int      `json:"unix_count"`
+	SharedCount  int      `json:"shared_count"` // sockets also held by other processes
+	ListenPorts  []int    `json:"listen_ports"`
+	Protos       []string `json:"protocols"` // distinct socket protocols, e.g. tcp, udp6, unix
+	LPortsText   string   `json:"-"`
 }
 
 func NewSummary(pid int, name string) *ProcessSummary {
@@ -28,16 +37,65 @@ func (p *ProcessSummary) WithAggregatedSockets(socks []socket.Socket) *ProcessSu
 	p.LSocketCount = aggregated.ListenCount
 	p.ESocketCount = aggregated.EstablishedCount
 	p.CSocketCount = aggregated.CloseCount
+	p.USocketCount = aggregated.UnixCount
+	p.SharedCount = 0
+	for _, s := range socks {
+		if s.IsShared() {
+			p.SharedCount++
+		}
+	}
 	return p
 }
 
+// Ports come out ascending, the sockets are read from maps and would reorder on every refresh
 func (p *ProcessSummary) WithFilteredListenPorts(socks []socket.Socket) *ProcessSummary {
 	listenPorts := []string{}
-	for _, socket := range socks {
-		if socket.State == "LISTEN" {
-			listenPorts = append(listenPorts, strconv.Itoa(socket.Port))
+	p.ListenPorts = []int{}
+	byPort := slices.SortedFunc(slices.Values(socks), func(a, b socket.Socket) int {
+		return cmp.Or(cmp.Compare(a.Port, b.Port), cmp.Compare(a.Proto, b.Proto), cmp.Compare(a.Inode, b.Inode))
+	})
+	for _, socket := range byPort {
+		if socket.State == "LISTEN" && !socket.IsUnix() {
+			p.ListenPorts = append(p.ListenPorts, socket.Port)
+			port := strconv.Itoa(socket.Port)
+			// listener inherited by other processes, e.g. prefork workers
+			if socket.IsShared() {
+				port += SharedMarker
+			}
+			listenPorts = append(listenPorts, port)
 		}
 	}
 	p.LPortsText = strings.Join(listenPorts, ",")
 	return p
 }
+
+func (p *ProcessSummary) WithProtocols(socks []socket.Socket) *ProcessSummary {
+	p.Protos = []string{}
+	seen := map[string]bool{}
+	for _, s := range socks {
+		if !seen[s.Proto] {
+			p.Protos = append(p.Protos, s.Proto)
+			seen[s.Proto] = true
+		}
+	}
+	slices.Sort(p.Protos)
+	return p
+}
+
+func (p ProcessSummary) ListensOn(port int) bool {
+	for _, lp := range p.ListenPorts {
+		if lp == port {
+			return true
+		}
+	}
+	return false
+}
+
+func (p ProcessSummary) HasProto(proto string) bool {
+	for _, pr := range p.Protos {
+		if pr == proto {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,9 @@
 package process
 
 type ProcessUser struct {
-	RealUID    int
-	Name       string
-	Privileged bool
+	RealUID    int    `json:"real_uid"`
+	Name       string `json:"name"`
+	Privileged bool   `json:"privileged"`
 }
 
 func (pu *ProcessUser) PrivilegedString() string {
@@ -2,68 +2,104 @@ package procfs
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"netps/internal/process"
 	"netps/internal/procfs/cmdline"
 	"netps/internal/procfs/comm"
 	"netps/internal/procfs/exe"
 	"netps/internal/procfs/net"
+	"netps/internal/procfs/root"
 	"netps/internal/procfs/stat"
 	"netps/internal/procfs/status"
 	"netps/internal/procfs/uptime"
 	"netps/internal/socket"
+	"os"
 	"os/user"
 	"strconv"
+	"syscall"
 )
 
-type Client struct{}
+// ErrForeignRoot is returned by Signal when the proc mount is not the one of our own PID namespace,
+// its pids would name other processes, or none at all, to kill(2)
+var ErrForeignRoot = errors.New("proc root is not this PID namespace's /proc, signals are only sent to local processes")
 
-func NewClient() *Client {
-	return &Client{}
+type Client struct {
+	fsys    root.FS
+	sockets net.SocketTable
+}
+
+// NewClient reads the proc mount fsys, root.Default() on the host itself
+func NewClient(fsys root.FS) *Client {
+	return &Client{
+		fsys:    fsys,
+		sockets: net.ProcTable{FS: fsys},
+	}
+}
+
+// WithSocketTable replaces the net/ table parser used to resolve socket inodes
+func (p *Client) WithSocketTable(table net.SocketTable) *Client {
+	p.sockets = table
+	return p
 }
 
 func (p *Client) ListRunnings(ctx context.Context) ([]process.ProcessSummary, error) {
-	runningSockets, err := net.ParseRunningSockets()
+	runningSockets, err := net.ParseRunningSockets(p.fsys, p.sockets)
 	if err != nil {
 		return nil, err
 	}
 
 	out := []process.ProcessSummary{}
 	for pid, sockets := range runningSockets {
-		name, err := comm.ParseProcessName(pid)
+		name, err := comm.ParseProcessName(p.fsys, pid)
 		if err != nil {
-			return nil, err
+			continue // exited between the fd walk and now
 		}
 		proc := process.NewSummary(pid, name).
 			WithAggregatedSockets(sockets).
-			WithFilteredListenPorts(sockets)
+			WithFilteredListenPorts(sockets).
+			WithProtocols(sockets)
 
 		out = append(out, *proc)
 	}
 	return out, nil
 }
 
+func (p *Client) Name(ctx context.Context, pid int) (string, error) {
+	return comm.ParseProcessName(p.fsys, pid)
+}
+
 func (p *Client) Detail(ctx context.Context, pid int) (process.ProcessDetail, error) {
-	execPath, err := exe.ParseProcExe(pid)
+	name, err := comm.ParseProcessName(p.fsys, pid)
 	if err != nil {
 		return process.ProcessDetail{}, err
 	}
-	command, err := cmdline.ParseCmdLine(pid)
+	execPath, err := exe.ParseProcExe(p.fsys, pid)
+	if err != nil {
+		return process.ProcessDetail{}, err
+	}
+	command, err := cmdline.ParseCmdLine(p.fsys, pid)
 	if err != nil {
 		return process.ProcessDetail{}, err
 	}
 
-	processStat, err := stat.ParseStat(pid)
+	processStat, err := stat.ParseStat(p.fsys, pid)
 	if err != nil {
-		return process.ProcessDetail{}, nil
+		return process.ProcessDetail{}, err
 	}
 	ppid := processStat.PPID
 
-	parentName, err := comm.ParseProcessName(ppid)
-	if err != nil {
-		return process.ProcessDetail{}, nil
+	// init and kernel threads hang off pid 0, which has no /proc entry
+	parentName := ""
+	if ppid > 0 {
+		parentName, err = comm.ParseProcessName(p.fsys, ppid)
+		if err != nil {
+			return process.ProcessDetail{}, err
+		}
 	}
 
 	detail := process.ProcessDetail{
+		Name:       name,
 		ExecPath:   execPath,
 		Command:    command,
 		PPID:       ppid,
@@ -74,7 +110,7 @@ func (p *Client) Detail(ctx context.Context, pid int) (process.ProcessDetail, er
 }
 
 func (p *Client) UpTime(ctx context.Context) (float64, error) {
-	upTime, err := uptime.ParseSystemUptime()
+	upTime, err := uptime.ParseSystemUptime(p.fsys)
 	if err != nil {
 		return -1, err
 	}
@@ -82,7 +118,7 @@ func (p *Client) UpTime(ctx context.Context) (float64, error) {
 }
 
 func (p *Client) Resource(ctx context.Context, pid int) (process.ProcessResource, error) {
-	processStat, err := stat.ParseStat(pid)
+	processStat, err := stat.ParseStat(p.fsys, pid)
 	if err != nil {
 		return process.ProcessResource{}, err
 	}
@@ -93,12 +129,13 @@ func (p *Client) Resource(ctx context.Context, pid int) (process.ProcessResource
 		VirtualMemorySize:      processStat.VSize,
 		UserCPUTimeClockTick:   processStat.UTime,
 		SystemCPUTimeClockTick: processStat.STime,
+		State:                  processStat.State,
 	}
 	return resource, nil
 }
 
 func (s *Client) User(ctx context.Context, pid int) (process.ProcessUser, error) {
-	realId, err := status.ParseRealUID(pid)
+	realId, err := status.ParseRealUID(s.fsys, pid)
 	if err != nil {
 		return process.ProcessUser{}, err
 	}
@@ -108,7 +145,7 @@ func (s *Client) User(ctx context.Context, pid int) (process.ProcessUser, error)
 		return process.ProcessUser{}, err
 	}
 
-	effectiveId, err := status.ParseEffectiveUID(pid)
+	effectiveId, err := status.ParseEffectiveUID(s.fsys, pid)
 	if err != nil {
 		return process.ProcessUser{}, err
 	}
@@ -122,9 +159,31 @@ func (s *Client) User(ctx context.Context, pid int) (process.ProcessUser, error)
 }
 
 func (s *Client) SocketsByStates(ctx context.Context, pid int, states []socket.SocketState) ([]socket.Socket, error) {
-	sockets, err := net.ParseSocketsByStates(pid, states)
+	sockets, err := net.ParseSocketsByStates(s.fsys, s.sockets, pid, states)
 	if err != nil {
 		return []socket.Socket{}, err
 	}
 	return sockets, nil
 }
+
+func (s *Client) RunningSockets(ctx context.Context) (map[int][]socket.Socket, error) {
+	return net.ParseRunningSockets(s.fsys, s.sockets)
+}
+
+func (s *Client) Signal(ctx context.Context, pid int, sig syscall.Signal) error {
+	// kill(2) treats pid 0 and negative pids as process groups, never allow that from here
+	if pid <= 0 {
+		return fmt.Errorf("invalid pid %d", pid)
+	}
+	if !s.ownNamespace() {
+		return ErrForeignRoot
+	}
+	return syscall.Kill(pid, sig)
+}
+
+// self resolves to the reader's pid as numbered by the namespace the mount belongs to,
+// a copied tree or the host's /proc seen from a container names something else
+func (s *Client) ownNamespace() bool {
+	self, err := s.fsys.ReadLink("self")
+	return err == nil && self == strconv.Itoa(os.Getpid())
+}
@@ -0,0 +1,82 @@
+package procfs
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"netps/internal/process"
+	"netps/internal/procfs/procfstest"
+	"netps/internal/procfs/root"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestClientListRunnings(t *testing.T) {
+	got, err := NewClient(procfstest.Tree(t, "host")).ListRunnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.SortFunc(got, func(a, b process.ProcessSummary) int { return cmp.Compare(a.PID, b.PID) })
+	procfstest.Golden(t, "list_runnings_host", got)
+}
+
+func TestClientDetail(t *testing.T) {
+	ctx := context.Background()
+	client := NewClient(procfstest.Tree(t, "host"))
+	type result struct {
+		Detail   procfstest.Outcome `json:"detail"`
+		Resource procfstest.Outcome `json:"resource"`
+	}
+	got := map[int]result{}
+	for _, pid := range []int{1, 2, 812, 813, 4000, 5000, 6000} {
+		got[pid] = result{
+			Detail:   procfstest.NewOutcome(client.Detail(ctx, pid)),
+			Resource: procfstest.NewOutcome(client.Resource(ctx, pid)),
+		}
+	}
+	procfstest.Golden(t, "detail_host", got)
+}
+
+func TestCollectRawFiles(t *testing.T) {
+	files, errs := CollectRawFiles(procfstest.Tree(t, "host"), []int{1, 2, 5000})
+	// pid 2 has neither exe nor a readable fd directory
+	if len(errs) != 2 {
+		t.Errorf("got %d errors, want 2: %v", len(errs), errs)
+	}
+	type entry struct {
+		Path string `json:"path"`
+		Link string `json:"link,omitempty"`
+		Size int    `json:"size"`
+	}
+	got := []entry{}
+	for _, f := range files {
+		got = append(got, entry{Path: f.Path, Link: f.Link, Size: len(f.Data)})
+	}
+	procfstest.Golden(t, "raw_files_host", got)
+}
+
+func TestClientSignalForeignRoot(t *testing.T) {
+	ctx := context.Background()
+	other := t.TempDir()
+	if err := os.Symlink(strconv.Itoa(os.Getpid()+1), filepath.Join(other, "self")); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name string
+		fsys root.FS
+		want error
+	}{
+		{"fixture tree", procfstest.Tree(t, "host"), ErrForeignRoot},
+		{"another pid namespace", root.Dir(other), ErrForeignRoot},
+		{"own /proc", root.Default(), nil},
+	}
+	for _, c := range cases {
+		// signal 0 only checks the pid, nothing is delivered
+		if err := NewClient(c.fsys).Signal(ctx, os.Getpid(), 0); !errors.Is(err, c.want) {
+			t.Errorf("%s: Signal() = %v, want %v", c.name, err, c.want)
+		}
+	}
+}
@@ -3,14 +3,14 @@ package cmdline
 import (
 	"bytes"
 	"fmt"
-	"os"
+	"netps/internal/procfs/root"
 	"strconv"
 	"strings"
 )
 
-func ParseCmdLine(pid int) (string, error) {
-	path := fmt.Sprintf("/proc/%d/cmdline", pid)
-	data, err := os.ReadFile(path)
+func ParseCmdLine(fsys root.FS, pid int) (string, error) {
+	path := fmt.Sprintf("%d/cmdline", pid)
+	data, err := fsys.ReadFile(path)
 	if err != nil {
 		return "", err
 	}
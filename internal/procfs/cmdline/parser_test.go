@@ -0,0 +1,30 @@
+package cmdline
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseCmdLine(t *testing.T) {
+	fsys := procfstest.Tree(t, "host")
+	got := map[int]procfstest.Outcome{}
+	for _, pid := range []int{1, 2, 812, 813, 4000, 5000, 6000} {
+		got[pid] = procfstest.NewOutcome(ParseCmdLine(fsys, pid))
+	}
+	procfstest.Golden(t, "cmdline_host", got)
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := map[string]string{
+		"/usr/bin/python3": "/usr/bin/python3",
+		"--flag=a b":       `"--flag=a b"`,
+		`print("$HOME")`:   `"print(\"$HOME\")"`,
+		"it's":             `"it's"`,
+		"tab\there":        `"tab\there"`,
+	}
+	for in, want := range cases {
+		if got := shellQuote([]byte(in)); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
@@ -2,20 +2,20 @@ package comm
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
-	"os"
+	"netps/internal/procfs/root"
 )
 
-func ParseProcessName(pid int) (string, error) {
-	path := fmt.Sprintf("/proc/%d/comm", pid)
+func ParseProcessName(fsys root.FS, pid int) (string, error) {
+	path := fmt.Sprintf("%d/comm", pid)
 
-	f, err := os.Open(path)
+	data, err := fsys.ReadFile(path)
 	if err != nil {
 		return "", err
 	}
-	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	if !scanner.Scan() {
 		return "", nil
 	}
@@ -0,0 +1,26 @@
+package comm
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseProcessName(t *testing.T) {
+	cases := []struct {
+		tree string
+		pids []int
+	}{
+		{"host", []int{1, 2, 812, 4000, 5000, 6000}},
+		{"truncated", []int{7000}},
+	}
+	for _, c := range cases {
+		t.Run(c.tree, func(t *testing.T) {
+			fsys := procfstest.Tree(t, c.tree)
+			got := map[int]procfstest.Outcome{}
+			for _, pid := range c.pids {
+				got[pid] = procfstest.NewOutcome(ParseProcessName(fsys, pid))
+			}
+			procfstest.Golden(t, "comm_"+c.tree, got)
+		})
+	}
+}
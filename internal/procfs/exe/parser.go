@@ -2,13 +2,13 @@ package exe
 
 import (
 	"fmt"
-	"os"
+	"netps/internal/procfs/root"
 )
 
-func ParseProcExe(pid int) (string, error) {
-	exePath, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pid))
+func ParseProcExe(fsys root.FS, pid int) (string, error) {
+	exePath, err := fsys.ReadLink(fmt.Sprintf("%d/exe", pid))
 	if err != nil {
-		panic(err)
+		return "", err
 	}
 
 	return exePath, nil
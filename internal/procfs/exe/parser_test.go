@@ -0,0 +1,16 @@
+package exe
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseProcExe(t *testing.T) {
+	fsys := procfstest.Tree(t, "host")
+	got := map[int]procfstest.Outcome{}
+	// kernel threads and zombies have no executable left to point at
+	for _, pid := range []int{1, 2, 812, 4000, 5000} {
+		got[pid] = procfstest.NewOutcome(ParseProcExe(fsys, pid))
+	}
+	procfstest.Golden(t, "exe_host", got)
+}
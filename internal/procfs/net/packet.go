@@ -0,0 +1,127 @@
+package net
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"netps/internal/procfs/root"
+	"netps/internal/socket"
+	"strconv"
+	"strings"
+)
+
+// Parses /proc/net/packet
+//
+// Format:
+// sk               RefCnt Type Proto  Iface R Rmem   User   Inode
+// ffff9e0a4a3c2000 3      3    0003   2     1 0      0      48121
+func parseProcNetPacket(fsys root.FS, path string) (map[uint64]socket.Socket, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := make(map[uint64]socket.Socket)
+	names := interfaceNames(fsys)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() {
+		return sockets, nil
+	}
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 9 {
+			continue
+		}
+
+		ethProto, err := strconv.ParseUint(fields[3], 16, 16)
+		if err != nil {
+			continue
+		}
+
+		ifIndex, err := strconv.Atoi(fields[4])
+		if err != nil {
+			continue
+		}
+
+		uid, err := strconv.Atoi(fields[7])
+		if err != nil {
+			continue
+		}
+
+		inode, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		sockets[inode] = socket.Socket{
+			Proto: socket.ProtoPacket,
+			Addr:  interfaceName(names, ifIndex),
+			Port:  int(ethProto),
+			State: socket.StateUnconnected,
+			Type:  parsePacketType(fields[2]),
+			UID:   uid,
+			Inode: inode,
+		}
+	}
+
+	return sockets, nil
+}
+
+func parsePacketType(t string) string {
+	switch t {
+	case "2":
+		return "dgram"
+	case "3":
+		return "raw"
+	default:
+		return "unknown"
+	}
+}
+
+// ifindex 0 means the socket is not bound and receives from every interface
+func interfaceName(names map[int]string, index int) string {
+	if index == 0 {
+		return "any"
+	}
+	if name, ok := names[index]; ok {
+		return name
+	}
+	return fmt.Sprintf("if%d", index)
+}
+
+// Interface names by index as the proc root sees them, not as this host does, so another
+// --proc-root or a copied tree is not labelled with local names. if_inet6 lists the interfaces
+// with an IPv6 address and dev_mcast those with a multicast address, nearly all of them together
+//
+// Format:
+// net/if_inet6:  fe800000000000000000000000000001 02 40 20 80     eth0
+// net/dev_mcast: 2    eth0            1     0     01005e000001
+func interfaceNames(fsys root.FS) map[int]string {
+	names := map[int]string{}
+	for _, table := range []struct {
+		path        string
+		index, name int
+		base        int
+	}{
+		{"net/if_inet6", 1, 5, 16},
+		{"net/dev_mcast", 0, 1, 10},
+	} {
+		data, err := fsys.ReadFile(table.path)
+		if err != nil {
+			continue // no IPv6 or no multicast, the index is shown instead
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) <= max(table.index, table.name) {
+				continue
+			}
+			index, err := strconv.ParseUint(fields[table.index], table.base, 31)
+			if err != nil {
+				continue
+			}
+			names[int(index)] = fields[table.name]
+		}
+	}
+	return names
+}
@@ -0,0 +1,15 @@
+package net
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseProcNetPacket(t *testing.T) {
+	for _, tree := range []string{"host", "truncated"} {
+		t.Run(tree, func(t *testing.T) {
+			got, err := parseProcNetPacket(procfstest.Tree(t, tree), "net/packet")
+			procfstest.Golden(t, "packet_"+tree, procfstest.NewOutcome(got, err))
+		})
+	}
+}
@@ -2,38 +2,77 @@ package net
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"log/slog"
 	"maps"
 	"net"
+	"netps/internal/procfs/root"
 	"netps/internal/socket"
 	"os"
-	"path/filepath"
+	"path"
 	"slices"
 	"strconv"
 	"strings"
 )
 
-func ParseSockets(pid int) ([]socket.Socket, error) {
+// SocketTable resolves socket inodes to sockets for the whole host
+type SocketTable interface {
+	InodeSockets() (map[uint64]socket.Socket, []error)
+}
+
+// ProcTable reads the socket tables under net/ of a proc mount.
+// Skip leaves out protocols another table already covers
+type ProcTable struct {
+	FS   root.FS
+	Skip []string
+}
+
+func (t ProcTable) InodeSockets() (map[uint64]socket.Socket, []error) {
+	return getInodeSocketMap(t.FS, t.Skip)
+}
+
+func ParseSockets(fsys root.FS, table SocketTable, pid int) ([]socket.Socket, error) {
 	sockets := []socket.Socket{}
-	inodes, err := getInodes(pid)
+	own, err := getSocketFDs(fsys, pid)
 	if err != nil {
 		return []socket.Socket{}, err
 	}
 
-	inodeSocketMap, errs := getInodeSocketMap()
+	inodeSocketMap, errs := table.InodeSockets()
 	for _, e := range errs {
 		slog.Error("ParseSockets() -> Error when parsing inode sockets map", "msg", e.Error())
 	}
 
+	ownersByInode := map[uint64][]socket.Owner{}
+	inodes := []uint64{}
+	for _, o := range own {
+		if _, ok := inodeSocketMap[o.inode]; !ok {
+			continue // netlink and other unsupported families have no entry
+		}
+		if _, ok := ownersByInode[o.inode]; !ok {
+			inodes = append(inodes, o.inode)
+		}
+		// same socket dup'ed on several fds
+		ownersByInode[o.inode] = append(ownersByInode[o.inode], socket.Owner{PID: pid, FD: o.fd})
+	}
+
+	// other holders are only known by walking the other processes' fds
+	otherOwners, err := findOtherOwners(fsys, pid, inodes)
+	if err != nil {
+		return []socket.Socket{}, err
+	}
+
 	for _, inode := range inodes {
-		sockets = append(sockets, inodeSocketMap[inode])
+		sock := inodeSocketMap[inode]
+		sock.Owners = append(ownersByInode[inode], otherOwners[inode]...)
+		sockets = append(sockets, sock)
 	}
 	return sockets, nil
 }
 
-func ParseSocketsByStates(pid int, state []socket.SocketState) ([]socket.Socket, error) {
-	socks, err := ParseSockets(pid)
+func ParseSocketsByStates(fsys root.FS, table SocketTable, pid int, state []socket.SocketState) ([]socket.Socket, error) {
+	socks, err := ParseSockets(fsys, table, pid)
 	if err != nil {
 		return []socket.Socket{}, err
 	}
@@ -42,21 +81,27 @@ func ParseSocketsByStates(pid int, state []socket.SocketState) ([]socket.Socket,
 	return filtered, nil
 }
 
-func ParseRunningSockets() (map[int][]socket.Socket, error) {
-	inodeSocketMap, errs := getInodeSocketMap()
+func ParseRunningSockets(fsys root.FS, table SocketTable) (map[int][]socket.Socket, error) {
+	inodeSocketMap, errs := table.InodeSockets()
 
 	for _, e := range errs {
 		slog.Error("ParseRunningSockets() -> Error when parsing inode sockets map", "msg", e.Error())
 	}
 
-	inodePID, err := mapInodeToPID()
+	inodeOwners, err := mapInodeToOwners(fsys)
 	if err != nil {
 		return nil, err
 	}
 
+	// a socket inherited across fork() belongs to every holder, not just one of them
 	procMap := make(map[int][]socket.Socket)
 	for inode, sock := range inodeSocketMap {
-		if pid, ok := inodePID[inode]; ok {
+		owners, ok := inodeOwners[inode]
+		if !ok {
+			continue
+		}
+		sock.Owners = owners
+		for _, pid := range sock.OwnerPIDs() {
 			procMap[pid] = append(procMap[pid], sock)
 		}
 	}
@@ -64,16 +109,15 @@ func ParseRunningSockets() (map[int][]socket.Socket, error) {
 	return procMap, nil
 }
 
-func parseProcNet(path string, proto string) (map[uint64]socket.Socket, error) {
-	f, err := os.Open(path)
+func parseProcNet(fsys root.FS, path string, proto string) (map[uint64]socket.Socket, error) {
+	data, err := fsys.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
 	sockets := make(map[uint64]socket.Socket)
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	if !scanner.Scan() {
 		return sockets, nil
 	}
@@ -87,6 +131,15 @@ func parseProcNet(path string, proto string) (map[uint64]socket.Socket, error) {
 		if err != nil {
 			continue
 		}
+		remAddr, remPort, err := parseHexAddr(fields[2])
+		if err != nil {
+			continue
+		}
+
+		uid, err := strconv.Atoi(fields[7])
+		if err != nil {
+			continue
+		}
 
 		inode, err := strconv.ParseUint(fields[9], 10, 64)
 		if err != nil {
@@ -94,10 +147,14 @@ func parseProcNet(path string, proto string) (map[uint64]socket.Socket, error) {
 		}
 
 		sockets[inode] = socket.Socket{
-			Proto: proto,
-			Addr:  addr,
-			Port:  port,
-			State: state,
+			Proto:      proto,
+			Addr:       addr,
+			Port:       port,
+			RemoteAddr: remAddr,
+			RemotePort: remPort,
+			State:      state,
+			UID:        uid,
+			Inode:      inode,
 		}
 	}
 
@@ -118,20 +175,30 @@ func parseHexAddr(s string) (string, int, error) {
 	ipHex := parts[0]
 	var ip net.IP
 
-	if len(ipHex) == 8 { // IPv4
+	switch len(ipHex) {
+	case 8: // IPv4
 		b := make([]byte, 4)
 		for i := 0; i < 4; i++ {
-			v, _ := strconv.ParseUint(ipHex[i*2:i*2+2], 16, 8)
+			v, err := strconv.ParseUint(ipHex[i*2:i*2+2], 16, 8)
+			if err != nil {
+				return "", 0, err
+			}
 			b[3-i] = byte(v)
 		}
 		ip = net.IP(b)
-	} else { // IPv6
+	case 32: // IPv6, four 32-bit words each in host (little endian) byte order
 		b := make([]byte, 16)
 		for i := 0; i < 16; i++ {
-			v, _ := strconv.ParseUint(ipHex[i*2:i*2+2], 16, 8)
-			b[15-i] = byte(v)
+			v, err := strconv.ParseUint(ipHex[i*2:i*2+2], 16, 8)
+			if err != nil {
+				return "", 0, err
+			}
+			word := i / 4
+			b[word*4+3-i%4] = byte(v)
 		}
 		ip = net.IP(b)
+	default:
+		return "", 0, fmt.Errorf("invalid addr %q", s)
 	}
 
 	return ip.String(), int(port64), nil
@@ -168,10 +235,10 @@ func parseState(state string) socket.SocketState {
 	}
 }
 
-func mapInodeToPID() (map[uint64]int, error) {
-	result := make(map[uint64]int)
+func mapInodeToOwners(fsys root.FS) (map[uint64][]socket.Owner, error) {
+	result := make(map[uint64][]socket.Owner)
 
-	procEntries, err := os.ReadDir("/proc")
+	procEntries, err := fsys.ReadDir(".")
 	if err != nil {
 		return nil, err
 	}
@@ -186,83 +253,160 @@ func mapInodeToPID() (map[uint64]int, error) {
 			continue
 		}
 
-		fdDir := filepath.Join("/proc", e.Name(), "fd")
-		fds, err := os.ReadDir(fdDir)
+		fds, err := getSocketFDs(fsys, pid)
 		if err != nil {
 			continue
 		}
-
 		for _, fd := range fds {
-			linkPath := filepath.Join(fdDir, fd.Name())
-			link, err := os.Readlink(linkPath)
-			if err != nil {
-				continue
-			}
+			result[fd.inode] = append(result[fd.inode], socket.Owner{PID: pid, FD: fd.fd})
+		}
+	}
+
+	return result, nil
+}
+
+// findOtherOwners collects every process besides pid holding one of inodes, a prefork listener
+// comes back with all of its workers
+func findOtherOwners(fsys root.FS, pid int, inodes []uint64) (map[uint64][]socket.Owner, error) {
+	result := make(map[uint64][]socket.Owner)
+	wanted := make(map[uint64]bool, len(inodes))
+	for _, inode := range inodes {
+		wanted[inode] = true
+	}
+	if len(wanted) == 0 {
+		return result, nil
+	}
 
-			if strings.HasPrefix(link, "socket:[") {
-				inodeStr := strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")
-				inode, err := strconv.ParseUint(inodeStr, 10, 64)
-				if err == nil {
-					result[inode] = pid
-				}
+	procEntries, err := fsys.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range procEntries {
+		if !e.IsDir() {
+			continue
+		}
+		other, err := strconv.Atoi(e.Name())
+		if err != nil || other == pid {
+			continue
+		}
+
+		fds, err := getSocketFDs(fsys, other)
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			if wanted[fd.inode] {
+				result[fd.inode] = append(result[fd.inode], socket.Owner{PID: other, FD: fd.fd})
 			}
 		}
 	}
-
 	return result, nil
 }
 
-func getInodeSocketMap() (map[uint64]socket.Socket, []error) {
+func getInodeSocketMap(fsys root.FS, skip []string) (map[uint64]socket.Socket, []error) {
 	inodeSocketMap := make(map[uint64]socket.Socket)
 	errors := []error{}
 	procNetfiles := []struct {
 		path  string
 		proto string
 	}{
-		{"/proc/net/tcp", "tcp"},
-		{"/proc/net/tcp6", "tcp6"},
-		{"/proc/net/udp", "udp"},
-		{"/proc/net/udp6", "udp6"},
+		{"net/tcp", socket.ProtoTCP},
+		{"net/tcp6", socket.ProtoTCP6},
+		{"net/udp", socket.ProtoUDP},
+		{"net/udp6", socket.ProtoUDP6},
+		{"net/udplite", socket.ProtoUDPLite},
+		{"net/udplite6", socket.ProtoUDPLite6},
+		{"net/raw", socket.ProtoRaw},
+		{"net/raw6", socket.ProtoRaw6},
 	}
 
 	for _, f := range procNetfiles {
-		m, err := parseProcNet(f.path, f.proto)
+		if slices.Contains(skip, f.proto) {
+			continue
+		}
+		m, err := parseProcNet(fsys, f.path, f.proto)
 		if err != nil {
 			errors = append(errors, err)
 			continue
 		}
 		maps.Copy(inodeSocketMap, m)
 	}
-	return inodeSocketMap, []error{}
+
+	// files with their own format, sctp only exists when the module is loaded
+	otherNetFiles := []struct {
+		path     string
+		proto    string
+		parse    func(fsys root.FS, path string) (map[uint64]socket.Socket, error)
+		optional bool
+	}{
+		{"net/unix", socket.ProtoUnix, parseProcNetUnix, false},
+		{"net/packet", socket.ProtoPacket, parseProcNetPacket, false},
+		{"net/sctp/eps", socket.ProtoSCTP, parseProcNetSCTPEndpoints, true},
+		{"net/sctp/assocs", socket.ProtoSCTP, parseProcNetSCTPAssocs, true},
+	}
+
+	for _, f := range otherNetFiles {
+		if slices.Contains(skip, f.proto) {
+			continue
+		}
+		m, err := f.parse(fsys, f.path)
+		if err != nil {
+			if !(f.optional && os.IsNotExist(err)) {
+				errors = append(errors, err)
+			}
+			continue
+		}
+		for inode, sock := range m {
+			// a one-to-many sctp socket shows up both as endpoint and association, keep the endpoint
+			if _, exists := inodeSocketMap[inode]; !exists {
+				inodeSocketMap[inode] = sock
+			}
+		}
+	}
+	return inodeSocketMap, errors
 }
 
-func getInodes(pid int) ([]uint64, error) {
+type socketFD struct {
+	fd    int
+	inode uint64
+}
 
-	result := []uint64{}
-	fdDir := filepath.Join("/proc", strconv.Itoa(pid), "fd")
-	fds, err := os.ReadDir(fdDir)
+// getSocketFDs returns the fds of pid pointing at a socket
+func getSocketFDs(fsys root.FS, pid int) ([]socketFD, error) {
+	result := []socketFD{}
+	fdDir := path.Join(strconv.Itoa(pid), "fd")
+	fds, err := fsys.ReadDir(fdDir)
 	if err != nil {
-		return []uint64{}, err
+		return []socketFD{}, err
 	}
 
 	for _, fd := range fds {
-		linkPath := filepath.Join(fdDir, fd.Name())
-		link, err := os.Readlink(linkPath)
+		fdNum, err := strconv.Atoi(fd.Name())
 		if err != nil {
 			continue
 		}
-
-		if strings.HasPrefix(link, "socket:[") {
-			inodeStr := strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")
-			inode, err := strconv.ParseUint(inodeStr, 10, 64)
-			if err == nil {
-				result = append(result, inode)
-			}
+		link, err := fsys.ReadLink(path.Join(fdDir, fd.Name()))
+		if err != nil {
+			continue
+		}
+		if inode, ok := socketInode(link); ok {
+			result = append(result, socketFD{fd: fdNum, inode: inode})
 		}
 	}
 	return result, nil
 }
 
+// socketInode parses the inode out of an fd link target, "socket:[12345]"
+func socketInode(link string) (uint64, bool) {
+	inodeStr, ok := strings.CutPrefix(link, "socket:[")
+	if !ok {
+		return 0, false
+	}
+	inode, err := strconv.ParseUint(strings.TrimSuffix(inodeStr, "]"), 10, 64)
+	return inode, err == nil
+}
+
 func filterSockets(socks []socket.Socket, states []socket.SocketState) []socket.Socket {
 	filtered := []socket.Socket{}
 	for _, sock := range socks {
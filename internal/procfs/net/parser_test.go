@@ -0,0 +1,187 @@
+package net
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"netps/internal/procfs/procfstest"
+	"netps/internal/socket"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseProcNet(t *testing.T) {
+	cases := []struct {
+		tree  string
+		file  string
+		proto string
+	}{
+		{"host", "tcp", socket.ProtoTCP},
+		{"host", "tcp6", socket.ProtoTCP6},
+		{"host", "udp", socket.ProtoUDP},
+		{"host", "udp6", socket.ProtoUDP6},
+		{"host", "raw", socket.ProtoRaw},
+		{"host", "udplite", socket.ProtoUDPLite},
+		{"truncated", "tcp", socket.ProtoTCP},
+		{"truncated", "tcp6", socket.ProtoTCP6},
+		{"truncated", "udp", socket.ProtoUDP},
+		{"truncated", "raw", socket.ProtoRaw},
+	}
+	for _, c := range cases {
+		t.Run(c.tree+"/"+c.file, func(t *testing.T) {
+			got, err := parseProcNet(procfstest.Tree(t, c.tree), "net/"+c.file, c.proto)
+			procfstest.Golden(t, "net_"+c.tree+"_"+c.file, procfstest.NewOutcome(got, err))
+		})
+	}
+}
+
+func TestInodeSockets(t *testing.T) {
+	for _, tree := range []string{"host", "truncated"} {
+		t.Run(tree, func(t *testing.T) {
+			got, errs := ProcTable{FS: procfstest.Tree(t, tree)}.InodeSockets()
+			procfstest.Golden(t, "inode_sockets_"+tree, procfstest.Partial(got, errs))
+		})
+	}
+}
+
+func TestInodeSocketsSkip(t *testing.T) {
+	got, errs := ProcTable{FS: procfstest.Tree(t, "host"), Skip: []string{socket.ProtoTCP, socket.ProtoUnix}}.InodeSockets()
+	for _, err := range errs {
+		t.Errorf("InodeSockets() on the host tree: %v", err)
+	}
+	for inode, s := range got {
+		if s.Proto == socket.ProtoTCP || s.Proto == socket.ProtoUnix {
+			t.Errorf("inode %d: skipped protocol %s was parsed", inode, s.Proto)
+		}
+	}
+	if _, ok := got[10003]; !ok {
+		t.Errorf("tcp6 listener 10003 missing, only tcp was skipped")
+	}
+}
+
+func TestParseRunningSockets(t *testing.T) {
+	fsys := procfstest.Tree(t, "host")
+	got, err := ParseRunningSockets(fsys, ProcTable{FS: fsys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// map order is random, sort each process' sockets so the golden file is stable
+	for pid := range got {
+		sortByInode(got[pid])
+	}
+	procfstest.Golden(t, "running_sockets_host", got)
+}
+
+func TestParseSocketsByStates(t *testing.T) {
+	fsys := procfstest.Tree(t, "host")
+	table := ProcTable{FS: fsys}
+	got := map[int]procfstest.Outcome{}
+	// pid 2 has no readable fd directory
+	for _, pid := range []int{2, 812, 813, 5000} {
+		socks, err := ParseSocketsByStates(fsys, table, pid, []socket.SocketState{socket.StateListen, socket.StateEstablished})
+		sortByInode(socks)
+		got[pid] = procfstest.NewOutcome(socks, err)
+	}
+	procfstest.Golden(t, "sockets_by_states_host", got)
+}
+
+func TestParseProcNetLargeTable(t *testing.T) {
+	const n = 100_000
+	var b strings.Builder
+	b.WriteString("  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "%5d: 0100007F:%04X 0100007F:1F90 01 00000000:00000000 00:00000000 00000000  1000        0 %d 1 ffff9e0a4b6a8000 20 4 30 10 -1\n",
+			i, 1024+i%60000, 1_000_000+i)
+	}
+	fsys := procfstest.TempTree(t, map[string]string{"net/tcp": b.String()})
+
+	got, err := parseProcNet(fsys, "net/tcp", socket.ProtoTCP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != n {
+		t.Fatalf("parsed %d sockets, want %d", len(got), n)
+	}
+	last := got[1_000_000+n-1]
+	if last.Port != 1024+(n-1)%60000 || last.RemotePort != 8080 || last.State != socket.StateEstablished {
+		t.Errorf("last socket = %+v", last)
+	}
+}
+
+func TestParseHexAddr(t *testing.T) {
+	cases := []struct {
+		in   string
+		addr string
+		port int
+		ok   bool
+	}{
+		{"0100007F:1F90", "127.0.0.1", 8080, true},
+		{"00000000:0050", "0.0.0.0", 80, true},
+		{"00000000000000000000000000000000:01BB", "::", 443, true},
+		{"00000000000000000000000001000000:0016", "::1", 22, true},
+		{"0000000000000000FFFF00000100007F:1F90", "127.0.0.1", 8080, true},
+		{"000080FE00000000FF0AB0E2FE2F7A0B:0222", "fe80::e2b0:aff:b7a:2ffe", 546, true},
+		{"0100007:1F90", "", 0, false},
+		{"B80D01200000000000000000010000000:0016", "", 0, false},
+		{"0100007G:1F90", "", 0, false},
+		{"0100007F:10000", "", 0, false},
+		{"0100007F", "", 0, false},
+		{"", "", 0, false},
+	}
+	for _, c := range cases {
+		addr, port, err := parseHexAddr(c.in)
+		if (err == nil) != c.ok {
+			t.Errorf("parseHexAddr(%q) error = %v, want ok %v", c.in, err, c.ok)
+			continue
+		}
+		if addr != c.addr || port != c.port {
+			t.Errorf("parseHexAddr(%q) = %s, %d, want %s, %d", c.in, addr, port, c.addr, c.port)
+		}
+	}
+}
+
+func FuzzParseHexAddr(f *testing.F) {
+	f.Add("0100007F:1F90")
+	f.Add("0000000000000000FFFF00000100007F:1F90")
+	f.Add("000080FE00000000FF0AB0E2FE2F7A0B:0222")
+	f.Add("0100007:1F90")
+	f.Add(":")
+	f.Fuzz(func(t *testing.T, in string) {
+		addr, port, err := parseHexAddr(in)
+		if err != nil {
+			return
+		}
+		ip := net.ParseIP(addr)
+		if ip == nil {
+			t.Fatalf("parseHexAddr(%q) = %q, not an address", in, addr)
+		}
+		// whatever was accepted must encode back to the same bytes
+		hexIP := strings.Split(in, ":")[0]
+		if got := encodeHexAddr(ip, len(hexIP) == 32); !strings.EqualFold(got, hexIP) {
+			t.Fatalf("parseHexAddr(%q) = %s, encodes back to %s", in, addr, got)
+		}
+		if port < 0 || port > 0xFFFF {
+			t.Fatalf("parseHexAddr(%q) port %d out of range", in, port)
+		}
+	})
+}
+
+// encodeHexAddr is the kernel's side of parseHexAddr, 32-bit words in little endian order
+func encodeHexAddr(ip net.IP, v6 bool) string {
+	b := ip.To16()
+	if !v6 {
+		b = ip.To4()
+	}
+	var out strings.Builder
+	for word := 0; word < len(b); word += 4 {
+		for i := 3; i >= 0; i-- {
+			fmt.Fprintf(&out, "%02X", b[word+i])
+		}
+	}
+	return out.String()
+}
+
+func sortByInode(socks []socket.Socket) {
+	slices.SortFunc(socks, func(a, b socket.Socket) int { return cmp.Compare(a.Inode, b.Inode) })
+}
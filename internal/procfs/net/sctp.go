@@ -0,0 +1,158 @@
+package net
+
+import (
+	"bufio"
+	"bytes"
+	"netps/internal/procfs/root"
+	"netps/internal/socket"
+	"strconv"
+	"strings"
+)
+
+// SCTP reuses TCP_LISTEN (10) for listening endpoints
+const sctpSocketStateListen = "10"
+
+// SCTP_STATE_ESTABLISHED in the kernel's sctp_state enum
+const sctpAssocStateEstablished = "3"
+
+// Parses /proc/net/sctp/eps, only present when the sctp module is loaded
+//
+// Format:
+// ENDPT            SOCK             STY SST HBKT LPORT   UID INODE  LADDRS
+// ffff88017e0a0200 ffff880299f7fa00 2   10  29   11165   0   209580 10.0.0.1 10.0.0.2
+func parseProcNetSCTPEndpoints(fsys root.FS, path string) (map[uint64]socket.Socket, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := make(map[uint64]socket.Socket)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() {
+		return sockets, nil
+	}
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 9 {
+			continue
+		}
+
+		port, err := strconv.Atoi(fields[5])
+		if err != nil {
+			continue
+		}
+
+		uid, err := strconv.Atoi(fields[6])
+		if err != nil {
+			continue
+		}
+
+		inode, err := strconv.ParseUint(fields[7], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		state := socket.StateClose
+		if fields[3] == sctpSocketStateListen {
+			state = socket.StateListen
+		}
+
+		addr := sctpPrimaryAddr(fields[8])
+		sockets[inode] = socket.Socket{
+			Proto: sctpProto(addr),
+			Addr:  addr,
+			Port:  port,
+			State: state,
+			UID:   uid,
+			Inode: inode,
+		}
+	}
+
+	return sockets, nil
+}
+
+// Parses /proc/net/sctp/assocs, one line per association
+//
+// Format:
+// ASSOC SOCK STY SST ST HBKT ASSOC-ID TX_QUEUE RX_QUEUE UID INODE LPORT RPORT LADDRS <-> RADDRS HBINT INS OUTS ...
+func parseProcNetSCTPAssocs(fsys root.FS, path string) (map[uint64]socket.Socket, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := make(map[uint64]socket.Socket)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() {
+		return sockets, nil
+	}
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 16 {
+			continue
+		}
+
+		uid, err := strconv.Atoi(fields[9])
+		if err != nil {
+			continue
+		}
+
+		inode, err := strconv.ParseUint(fields[10], 10, 64)
+		if err != nil {
+			continue
+		}
+		port, err := strconv.Atoi(fields[11])
+		if err != nil {
+			continue
+		}
+		remPort, err := strconv.Atoi(fields[12])
+		if err != nil {
+			continue
+		}
+
+		// address lists are variable length and separated by "<->"
+		sep := -1
+		for i := 13; i < len(fields); i++ {
+			if fields[i] == "<->" {
+				sep = i
+				break
+			}
+		}
+		if sep < 14 || sep+1 >= len(fields) {
+			continue
+		}
+
+		state := socket.StateClose
+		if fields[4] == sctpAssocStateEstablished {
+			state = socket.StateEstablished
+		}
+
+		addr := sctpPrimaryAddr(fields[13])
+		sockets[inode] = socket.Socket{
+			Proto:      sctpProto(addr),
+			Addr:       addr,
+			Port:       port,
+			RemoteAddr: sctpPrimaryAddr(fields[sep+1]),
+			RemotePort: remPort,
+			State:      state,
+			UID:        uid,
+			Inode:      inode,
+		}
+	}
+
+	return sockets, nil
+}
+
+// the primary address of an association is prefixed with "*"
+func sctpPrimaryAddr(addr string) string {
+	return strings.TrimPrefix(addr, "*")
+}
+
+func sctpProto(addr string) string {
+	if strings.Contains(addr, ":") {
+		return socket.ProtoSCTP6
+	}
+	return socket.ProtoSCTP
+}
@@ -0,0 +1,18 @@
+package net
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseProcNetSCTP(t *testing.T) {
+	for _, tree := range []string{"host", "truncated"} {
+		t.Run(tree, func(t *testing.T) {
+			fsys := procfstest.Tree(t, tree)
+			eps, err := parseProcNetSCTPEndpoints(fsys, "net/sctp/eps")
+			procfstest.Golden(t, "sctp_eps_"+tree, procfstest.NewOutcome(eps, err))
+			assocs, err := parseProcNetSCTPAssocs(fsys, "net/sctp/assocs")
+			procfstest.Golden(t, "sctp_assocs_"+tree, procfstest.NewOutcome(assocs, err))
+		})
+	}
+}
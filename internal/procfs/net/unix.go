@@ -0,0 +1,109 @@
+package net
+
+import (
+	"bufio"
+	"bytes"
+	"netps/internal/procfs/root"
+	"netps/internal/socket"
+	"strconv"
+	"strings"
+)
+
+// __SO_ACCEPTCON, set on sockets that called listen(2)
+const unixFlagAcceptCon = 0x10000
+
+// Parses /proc/net/unix
+//
+// Format:
+// Num       RefCount Protocol Flags    Type St Inode Path
+// 0000000018ca33cd: 00000002 00000000 00010000 0001 01  6945 /run/docker.sock
+func parseProcNetUnix(fsys root.FS, path string) (map[uint64]socket.Socket, error) {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sockets := make(map[uint64]socket.Socket)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	if !scanner.Scan() {
+		return sockets, nil
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		fields := strings.Fields(line)
+		if len(fields) < 7 {
+			continue
+		}
+
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil {
+			continue
+		}
+
+		inode, err := strconv.ParseUint(fields[6], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// the kernel writes the path verbatim after a single space, runs of
+		// spaces, tabs and trailing spaces all belong to it
+		path := unixPath(line)
+
+		sockets[inode] = socket.Socket{
+			Proto: socket.ProtoUnix,
+			State: parseUnixState(fields[5], flags),
+			Path:  path,
+			Type:  parseUnixType(fields[4]),
+			UID:   -1, // not exposed by /proc/net/unix
+			Inode: inode,
+		}
+	}
+
+	return sockets, nil
+}
+
+// Returns what follows the inode column, the 7th field, minus its separator
+func unixPath(line string) string {
+	rest := line
+	for range 7 {
+		rest = strings.TrimLeft(rest, " ")
+		end := strings.IndexByte(rest, ' ')
+		if end < 0 {
+			return ""
+		}
+		rest = rest[end:]
+	}
+	return rest[1:]
+}
+
+func parseUnixState(state string, flags uint64) socket.SocketState {
+	if flags&unixFlagAcceptCon != 0 {
+		return socket.StateListen
+	}
+	switch state {
+	case "01":
+		return socket.StateUnconnected
+	case "02":
+		return socket.StateConnecting
+	case "03":
+		return socket.StateEstablished
+	case "04":
+		return socket.StateDisconnecting
+	default:
+		return socket.StateUnknown
+	}
+}
+
+func parseUnixType(t string) string {
+	switch t {
+	case "0001":
+		return "stream"
+	case "0002":
+		return "dgram"
+	case "0005":
+		return "seqpacket"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,47 @@
+package net
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseProcNetUnix(t *testing.T) {
+	for _, tree := range []string{"host", "truncated"} {
+		t.Run(tree, func(t *testing.T) {
+			got, err := parseProcNetUnix(procfstest.Tree(t, tree), "net/unix")
+			procfstest.Golden(t, "unix_"+tree, procfstest.NewOutcome(got, err))
+		})
+	}
+}
+
+func TestParseProcNetUnixPaths(t *testing.T) {
+	const header = "Num       RefCount Protocol Flags    Type St Inode Path\n"
+	paths := map[uint64]string{
+		101: "/run/two  spaces.sock",
+		102: "/tmp/tab\there.sock",
+		103: "/tmp/trailing ",
+		104: "@abstract name",
+		105: "",
+		106: " leading",
+	}
+	fsys := procfstest.TempTree(t, map[string]string{"net/unix": header +
+		"0000000000000000: 00000002 00000000 00010000 0001 01   101 /run/two  spaces.sock\n" +
+		"0000000000000000: 00000002 00000000 00000000 0002 01   102 /tmp/tab\there.sock\n" +
+		"0000000000000000: 00000002 00000000 00000000 0001 03   103 /tmp/trailing \n" +
+		"0000000000000000: 00000002 00000000 00000000 0001 03   104 @abstract name\n" +
+		"0000000000000000: 00000002 00000000 00000000 0001 03   105\n" +
+		"0000000000000000: 00000002 00000000 00000000 0001 03 106  leading\n",
+	})
+	got, err := parseProcNetUnix(fsys, "net/unix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(paths) {
+		t.Errorf("got %d sockets, want %d", len(got), len(paths))
+	}
+	for inode, want := range paths {
+		if sock := got[inode]; sock.Path != want {
+			t.Errorf("inode %d: path %q, want %q", inode, sock.Path, want)
+		}
+	}
+}
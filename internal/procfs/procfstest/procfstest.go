@@ -0,0 +1,110 @@
+// Package procfstest serves the fixture proc trees under testdata/ to the
+// parser tests and compares their output with golden files.
+//
+// Trees:
+//   - host: a small machine with systemd, a kernel thread, an nginx master and
+//     worker sharing listeners, a zombie and a process whose comm holds spaces
+//     and parentheses. Sockets cover tcp, IPv4-mapped tcp6, udp, raw, unix,
+//     packet and sctp
+//   - truncated: files cut short, missing fields and malformed addresses
+//
+// Run the tests with -update to rewrite the golden files after a deliberate change.
+package procfstest
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"netps/internal/procfs/root"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files under testdata/ with the current output")
+
+// corpus is the testdata/ directory next to this file, whichever package runs the test
+var corpus = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "testdata")
+}()
+
+// Tree returns the fixture proc mount called name
+func Tree(t testing.TB, name string) root.FS {
+	t.Helper()
+	dir := filepath.Join(corpus, name)
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("fixture tree %q: %v", name, err)
+	}
+	return root.Dir(dir)
+}
+
+// TempTree writes files, named relative to the mount, into a fresh directory and serves it
+func TempTree(t testing.TB, files map[string]string) root.FS {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root.Dir(dir)
+}
+
+// Outcome is what a parser returned, errors are kept as text so they show up in the golden file
+type Outcome struct {
+	Value any    `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func NewOutcome(value any, err error) Outcome {
+	if err != nil {
+		return Outcome{Error: err.Error()}
+	}
+	return Outcome{Value: value}
+}
+
+// Partial is the Outcome of a parser that keeps going past errors, the value read so far is kept next to them
+func Partial(value any, errs []error) Outcome {
+	out := Outcome{Value: value}
+	if err := errors.Join(errs...); err != nil {
+		out.Error = err.Error()
+	}
+	return out
+}
+
+// Golden compares got, as indented JSON, with testdata/<name>.golden of the calling package.
+// The corpus location is replaced by "testdata" so error paths do not depend on the checkout
+func Golden(t testing.TB, name string, got any) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal %s: %v", name, err)
+	}
+	data = append([]byte(strings.ReplaceAll(string(data), corpus, "testdata")), '\n')
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run the tests with -update to create it", err)
+	}
+	if string(want) != string(data) {
+		t.Errorf("%s differs from %s, run the tests with -update if the change is intended\n got:\n%s\nwant:\n%s", name, path, data, want)
+	}
+}
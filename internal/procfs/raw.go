@@ -0,0 +1,76 @@
+package procfs
+
+import (
+	"errors"
+	"io/fs"
+	"netps/internal/procfs/root"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// A file as read under /proc, Link is set instead of Data for symlinks
+type RawFile struct {
+	Path string
+	Link string
+	Data []byte
+}
+
+// Every /proc file the parsers read, the net tables only exist for loaded protocols
+var rawNetFiles = []string{"tcp", "tcp6", "udp", "udp6", "udplite", "udplite6", "raw", "raw6", "unix", "packet", "if_inet6", "dev_mcast", "sctp/eps", "sctp/assocs"}
+
+var rawProcessFiles = []string{"stat", "status", "cmdline", "comm"}
+
+// CollectRawFiles copies the host wide files and those of the given processes as they are now.
+// Only socket fds are kept, the others may name private files. Paths are recorded under /proc
+// whatever the mount fsys was read from
+func CollectRawFiles(fsys root.FS, pids []int) ([]RawFile, []error) {
+	out := []RawFile{}
+	errs := []error{}
+	add := func(name string) {
+		data, err := fsys.ReadFile(name)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			return
+		}
+		out = append(out, RawFile{Path: path.Join(root.DefaultPath, name), Data: data})
+	}
+	addLink := func(name string) {
+		link, err := fsys.ReadLink(name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		out = append(out, RawFile{Path: path.Join(root.DefaultPath, name), Link: link})
+	}
+
+	add("uptime")
+	for _, name := range rawNetFiles {
+		add(path.Join("net", name))
+	}
+
+	for _, pid := range pids {
+		dir := strconv.Itoa(pid)
+		for _, name := range rawProcessFiles {
+			add(path.Join(dir, name))
+		}
+		addLink(path.Join(dir, "exe"))
+
+		fds, err := fsys.ReadDir(path.Join(dir, "fd"))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, fd := range fds {
+			name := path.Join(dir, "fd", fd.Name())
+			link, err := fsys.ReadLink(name)
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			out = append(out, RawFile{Path: path.Join(root.DefaultPath, name), Link: link})
+		}
+	}
+	return out, errs
+}
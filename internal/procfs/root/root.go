@@ -0,0 +1,61 @@
+package root
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DefaultPath is where the kernel mounts proc on a regular host
+const DefaultPath = "/proc"
+
+// FS is the proc mount the parsers read from, names are relative to it ("net/tcp", "1234/stat").
+// Links are read without being followed, a fd link names a socket that is not a file
+type FS interface {
+	fs.ReadFileFS
+	fs.ReadDirFS
+	ReadLink(name string) (string, error)
+}
+
+type dirFS struct {
+	fs.FS
+	path string
+}
+
+// Dir serves a proc mount found at path, /proc or a host's proc bind-mounted
+// into a container, or a directory laid out the same way
+func Dir(path string) FS {
+	return dirFS{FS: os.DirFS(path), path: path}
+}
+
+// Default is the proc mount of the host netps runs on
+func Default() FS {
+	return Dir(DefaultPath)
+}
+
+func (d dirFS) ReadFile(name string) ([]byte, error) {
+	data, err := fs.ReadFile(d.FS, name)
+	return data, d.fullPath(err)
+}
+
+func (d dirFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(d.FS, name)
+	return entries, d.fullPath(err)
+}
+
+func (d dirFS) ReadLink(name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+	}
+	return os.Readlink(filepath.Join(d.path, filepath.FromSlash(name)))
+}
+
+// os.DirFS reports names relative to the mount, errors are easier to read with the real path
+func (d dirFS) fullPath(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		pathErr.Path = filepath.Join(d.path, filepath.FromSlash(pathErr.Path))
+	}
+	return err
+}
@@ -3,38 +3,29 @@ package stat
 import (
 	"errors"
 	"fmt"
-	"os"
+	"netps/internal/procfs/root"
 	"strconv"
 	"strings"
 )
 
-func ParseStat(pid int) (*Stat, error) {
-	path := fmt.Sprintf("/proc/%d/stat", pid)
+func ParseStat(fsys root.FS, pid int) (*Stat, error) {
+	path := fmt.Sprintf("%d/stat", pid)
 
-	data, err := os.ReadFile(path)
+	data, err := fsys.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	line := string(data)
-
-	// 1. Find "(comm)" safely
-	open := strings.IndexByte(line, '(')
-	close := strings.LastIndexByte(line, ')')
-	if open < 0 || close < 0 || close <= open {
-		return nil, errors.New("invalid stat format: comm")
+	pidStr, comm, rest, err := splitComm(string(data))
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Parse fixed parts
-	pidStr := strings.TrimSpace(line[:open])
-	comm := line[open+1 : close]
-
 	pidParsed, err := strconv.Atoi(pidStr)
 	if err != nil {
 		return nil, err
 	}
 
-	// 3. Remaining fields (after ") ")
-	fields := strings.Fields(line[close+1:])
+	fields := strings.Fields(rest)
 	if len(fields) < 40 {
 		return nil, errors.New("invalid stat format: too few fields")
 	}
@@ -64,6 +55,17 @@ func ParseStat(pid int) (*Stat, error) {
 	}, nil
 }
 
+// splitComm cuts a stat line around "(comm)". comm may itself hold spaces and
+// parentheses, so it runs from the first '(' to the last ')'
+func splitComm(line string) (pid string, comm string, rest string, err error) {
+	open := strings.IndexByte(line, '(')
+	close := strings.LastIndexByte(line, ')')
+	if open < 0 || close < 0 || close <= open {
+		return "", "", "", errors.New("invalid stat format: comm")
+	}
+	return strings.TrimSpace(line[:open]), line[open+1 : close], line[close+1:], nil
+}
+
 func mustInt(s string) int {
 	v, _ := strconv.Atoi(s)
 	return v
@@ -0,0 +1,75 @@
+package stat
+
+import (
+	"fmt"
+	"netps/internal/procfs/procfstest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseStat(t *testing.T) {
+	cases := []struct {
+		tree string
+		pids []int
+	}{
+		{"host", []int{1, 2, 812, 813, 4000, 5000, 6000}},
+		{"truncated", []int{7000, 7001, 7002}},
+	}
+	for _, c := range cases {
+		t.Run(c.tree, func(t *testing.T) {
+			fsys := procfstest.Tree(t, c.tree)
+			got := map[int]procfstest.Outcome{}
+			for _, pid := range c.pids {
+				got[pid] = procfstest.NewOutcome(ParseStat(fsys, pid))
+			}
+			procfstest.Golden(t, "stat_"+c.tree, got)
+		})
+	}
+}
+
+func TestSplitComm(t *testing.T) {
+	cases := []struct {
+		line, pid, comm, rest string
+		ok                    bool
+	}{
+		{"1 (systemd) S 0", "1", "systemd", " S 0", true},
+		{"5000 (evil) (x y) S 1", "5000", "evil) (x y", " S 1", true},
+		{"42 () R 1", "42", "", " R 1", true},
+		{"42 (a)b) R", "42", "a)b", " R", true},
+		{"7000 (cut", "", "", "", false},
+		{"7000 cut) S", "", "", "", false},
+		{"7000 )( S", "", "", "", false},
+		{"", "", "", "", false},
+	}
+	for _, c := range cases {
+		pid, comm, rest, err := splitComm(c.line)
+		if (err == nil) != c.ok {
+			t.Errorf("splitComm(%q) error = %v, want ok %v", c.line, err, c.ok)
+			continue
+		}
+		if pid != c.pid || comm != c.comm || rest != c.rest {
+			t.Errorf("splitComm(%q) = %q, %q, %q, want %q, %q, %q", c.line, pid, comm, rest, c.pid, c.comm, c.rest)
+		}
+	}
+}
+
+func FuzzSplitComm(f *testing.F) {
+	f.Add(1, "systemd", "S 0 1 1 0 -1")
+	f.Add(5000, "evil) (x y", "S 1 5000")
+	f.Add(42, "", "")
+	f.Add(-1, "((", ") )")
+	f.Fuzz(func(t *testing.T, pid int, comm string, rest string) {
+		// the kernel never writes a ')' after comm, a line with one there is not well formed
+		rest = strings.ReplaceAll(rest, ")", "")
+		line := fmt.Sprintf("%d (%s) %s", pid, comm, rest)
+
+		gotPid, gotComm, gotRest, err := splitComm(line)
+		if err != nil {
+			t.Fatalf("splitComm(%q): %v", line, err)
+		}
+		if gotPid != strconv.Itoa(pid) || gotComm != comm || gotRest != " "+rest {
+			t.Fatalf("splitComm(%q) = %q, %q, %q", line, gotPid, gotComm, gotRest)
+		}
+	})
+}
@@ -2,21 +2,21 @@ package status
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
-	"os"
+	"netps/internal/procfs/root"
 	"strconv"
 	"strings"
 )
 
 // RealUID returns the real (owner) UID of the process.
-func ParseRealUID(pid int) (int, error) {
-	f, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+func ParseRealUID(fsys root.FS, pid int) (int, error) {
+	data, err := fsys.ReadFile(fmt.Sprintf("%d/status", pid))
 	if err != nil {
 		return 0, err
 	}
-	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	for scanner.Scan() {
 		line := scanner.Text()
 
@@ -38,14 +38,13 @@ func ParseRealUID(pid int) (int, error) {
 	return 0, fmt.Errorf("Uid field not found")
 }
 
-func ParseEffectiveUID(pid int) (int, error) {
-	f, err := os.Open(fmt.Sprintf("/proc/%d/status", pid))
+func ParseEffectiveUID(fsys root.FS, pid int) (int, error) {
+	data, err := fsys.ReadFile(fmt.Sprintf("%d/status", pid))
 	if err != nil {
 		return 0, err
 	}
-	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
 	for scanner.Scan() {
 		line := scanner.Text()
 		if strings.HasPrefix(line, "Uid:") {
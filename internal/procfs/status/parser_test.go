@@ -0,0 +1,33 @@
+package status
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseUID(t *testing.T) {
+	cases := []struct {
+		tree string
+		pids []int
+	}{
+		{"host", []int{1, 813, 4000, 5000, 6000}},
+		{"truncated", []int{7000, 7001, 7002}},
+	}
+	type uids struct {
+		Real      procfstest.Outcome `json:"real"`
+		Effective procfstest.Outcome `json:"effective"`
+	}
+	for _, c := range cases {
+		t.Run(c.tree, func(t *testing.T) {
+			fsys := procfstest.Tree(t, c.tree)
+			got := map[int]uids{}
+			for _, pid := range c.pids {
+				got[pid] = uids{
+					Real:      procfstest.NewOutcome(ParseRealUID(fsys, pid)),
+					Effective: procfstest.NewOutcome(ParseEffectiveUID(fsys, pid)),
+				}
+			}
+			procfstest.Golden(t, "uid_"+c.tree, got)
+		})
+	}
+}
@@ -1,16 +1,20 @@
 package uptime
 
 import (
-	"os"
+	"errors"
+	"netps/internal/procfs/root"
 	"strconv"
 	"strings"
 )
 
-func ParseSystemUptime() (float64, error) {
-	data, err := os.ReadFile("/proc/uptime")
+func ParseSystemUptime(fsys root.FS) (float64, error) {
+	data, err := fsys.ReadFile("uptime")
 	if err != nil {
 		return 0, err
 	}
 	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, errors.New("empty uptime")
+	}
 	return strconv.ParseFloat(fields[0], 64)
 }
@@ -0,0 +1,15 @@
+package uptime
+
+import (
+	"netps/internal/procfs/procfstest"
+	"testing"
+)
+
+func TestParseSystemUptime(t *testing.T) {
+	got := map[string]procfstest.Outcome{}
+	for _, tree := range []string{"host", "truncated"} {
+		got[tree] = procfstest.NewOutcome(ParseSystemUptime(procfstest.Tree(t, tree)))
+	}
+	got["garbage"] = procfstest.NewOutcome(ParseSystemUptime(procfstest.TempTree(t, map[string]string{"uptime": "up 3 days\n"})))
+	procfstest.Golden(t, "uptime", got)
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"netps/internal/process"
+	"netps/internal/socket"
+	"time"
+)
+
+// Section status, mirrors the detail screen hydration: a section either
+// hydrated (its data may still be empty, e.g. no sockets) or failed
+const (
+	SectionOK          = "ok"
+	SectionUnavailable = "unavailable"
+	SectionGone        = "gone" // the process exited before the section was read
+)
+
+type Inspection struct {
+	PID         int       `json:"pid"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Detail      Section   `json:"detail"`
+	Resource    Section   `json:"resource"`
+	User        Section   `json:"user"`
+	Sockets     Section   `json:"sockets"`
+}
+
+// Data is null unless Status is ok
+type Section struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   any    `json:"data"`
+}
+
+// Inspect reads every section of a process, a failing section does not stop the others
+func Inspect(ctx context.Context, processService *process.Service, socketService *socket.Service, pid int) Inspection {
+	inspection := Inspection{PID: pid, GeneratedAt: time.Now().UTC()}
+
+	detail, err := processService.GetProcessDetail(ctx, pid)
+	inspection.Detail = NewSection(NewDetail(detail), err)
+
+	resource, err := processService.GetProcessResource(ctx, pid)
+	inspection.Resource = NewSection(NewResource(resource), err)
+
+	user, err := processService.GetUser(ctx, pid)
+	inspection.User = NewSection(NewUser(user), err)
+
+	sockets, err := socketService.GetSocketsByStates(ctx, pid, SocketStates)
+	inspection.Sockets = NewSection(NewSockets(sockets), err)
+
+	return inspection
+}
+
+func NewSection(data any, err error) Section {
+	if err == nil {
+		return Section{Status: SectionOK, Data: data}
+	}
+	status := SectionUnavailable
+	if IsGone(err) {
+		status = SectionGone
+	}
+	return Section{Status: status, Error: err.Error()}
+}
+
+// IsGone reports whether err means the process no longer exists
+func IsGone(err error) bool {
+	return errors.Is(err, process.ErrProcessGone) || errors.Is(err, fs.ErrNotExist)
+}
+
+// AllGone reports whether no section could be read because there is no such process
+func (i Inspection) AllGone() bool {
+	for _, s := range []Section{i.Detail, i.Resource, i.User, i.Sockets} {
+		if s.Status != SectionGone {
+			return false
+		}
+	}
+	return true
+}
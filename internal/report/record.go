@@ -0,0 +1,133 @@
+// Package report holds the machine-readable shapes of the domain types, shared by
+// the CLI and the API. Durations are flattened to plain numbers with the unit in
+// the key so scripts never see nanoseconds
+package report
+
+import (
+	"netps/internal/process"
+	"netps/internal/socket"
+)
+
+type Detail struct {
+	Name       string `json:"name"`
+	ExecPath   string `json:"exec_path"`
+	Command    string `json:"command"`
+	PPID       int    `json:"ppid"`
+	ParentName string `json:"parent_name"`
+}
+
+type Resource struct {
+	State            string `json:"state"`
+	RSSBytes         int64  `json:"rss_bytes"`
+	VSZBytes         uint64 `json:"vsz_bytes"`
+	StartTimeSeconds int64  `json:"start_time_seconds"` // since boot
+	ElapsedSeconds   int64  `json:"elapsed_seconds"`
+	UserCPUSeconds   int64  `json:"user_cpu_seconds"`
+	SystemCPUSeconds int64  `json:"system_cpu_seconds"`
+}
+
+type User struct {
+	UID        int    `json:"uid"`
+	Name       string `json:"name"`
+	Privileged bool   `json:"privileged"`
+}
+
+type Socket struct {
+	Proto      string   `json:"proto"`
+	State      string   `json:"state"`
+	LocalAddr  string   `json:"local_addr"`
+	LocalPort  int      `json:"local_port"`
+	RemoteAddr string   `json:"remote_addr"`
+	RemotePort int      `json:"remote_port"`
+	Path       string   `json:"path,omitempty"`
+	Type       string   `json:"type,omitempty"`
+	UID        int      `json:"uid"`
+	Inode      uint64   `json:"inode"`
+	Owners     []Owner  `json:"owners"`
+	TCPInfo    *TCPInfo `json:"tcp_info,omitempty"`
+}
+
+type Owner struct {
+	PID int `json:"pid"`
+	FD  int `json:"fd"`
+}
+
+type TCPInfo struct {
+	RTTMicros        int64  `json:"rtt_us"`
+	RTTVarMicros     int64  `json:"rtt_var_us"`
+	Retransmits      uint32 `json:"retransmits"`
+	CongestionWindow uint32 `json:"congestion_window"`
+	BytesAcked       uint64 `json:"bytes_acked"`
+	BytesReceived    uint64 `json:"bytes_received"`
+}
+
+// Same states the detail screen asks for
+var SocketStates = []socket.SocketState{socket.StateListen, socket.StateEstablished, socket.StateClose, socket.StateUnconnected}
+
+func NewDetail(d process.ProcessDetail) Detail {
+	return Detail{
+		Name:       d.Name,
+		ExecPath:   d.ExecPath,
+		Command:    d.Command,
+		PPID:       d.PPID,
+		ParentName: d.ParentName,
+	}
+}
+
+func NewResource(r process.ProcessResource) Resource {
+	return Resource{
+		State:            r.State,
+		RSSBytes:         r.ResidentSetSizeByte,
+		VSZBytes:         r.VirtualMemorySize,
+		StartTimeSeconds: int64(r.StartTimeSec.Seconds()),
+		ElapsedSeconds:   int64(r.ElapsedTimeSec.Seconds()),
+		UserCPUSeconds:   int64(r.UserCPUTimeSecond.Seconds()),
+		SystemCPUSeconds: int64(r.SystemCPUTimeSecond.Seconds()),
+	}
+}
+
+func NewUser(u process.ProcessUser) User {
+	return User{
+		UID:        u.RealUID,
+		Name:       u.Name,
+		Privileged: u.Privileged,
+	}
+}
+
+func NewSocket(s socket.Socket) Socket {
+	r := Socket{
+		Proto:      s.Proto,
+		State:      string(s.State),
+		LocalAddr:  s.Addr,
+		LocalPort:  s.Port,
+		RemoteAddr: s.RemoteAddr,
+		RemotePort: s.RemotePort,
+		Path:       s.Path,
+		Type:       s.Type,
+		UID:        s.UID,
+		Inode:      s.Inode,
+		Owners:     []Owner{},
+	}
+	for _, o := range s.Owners {
+		r.Owners = append(r.Owners, Owner{PID: o.PID, FD: o.FD})
+	}
+	if s.TCPInfo != nil {
+		r.TCPInfo = &TCPInfo{
+			RTTMicros:        s.TCPInfo.RTT.Microseconds(),
+			RTTVarMicros:     s.TCPInfo.RTTVar.Microseconds(),
+			Retransmits:      s.TCPInfo.Retransmits,
+			CongestionWindow: s.TCPInfo.CongestionWindow,
+			BytesAcked:       s.TCPInfo.BytesAcked,
+			BytesReceived:    s.TCPInfo.BytesReceived,
+		}
+	}
+	return r
+}
+
+func NewSockets(sockets []socket.Socket) []Socket {
+	out := make([]Socket, 0, len(sockets))
+	for _, s := range sockets {
+		out = append(out, NewSocket(s))
+	}
+	return out
+}
@@ -0,0 +1,184 @@
+package snapshot
+
+import (
+	"netps/internal/socket"
+	"slices"
+	"sort"
+	"time"
+)
+
+type Diff struct {
+	Before      Meta              `json:"before"`
+	After       Meta              `json:"after"`
+	Appeared    []ProcessRef      `json:"processes_appeared"`
+	Gone        []ProcessRef      `json:"processes_gone"`
+	PortsOpened []PortChange      `json:"ports_opened"`
+	PortsClosed []PortChange      `json:"ports_closed"`
+	PortsMoved  []PortChange      `json:"ports_moved"`
+	Connections []ConnectionDelta `json:"connection_deltas"`
+}
+
+type Meta struct {
+	CapturedAt time.Time `json:"captured_at"`
+	Hostname   string    `json:"hostname"`
+	Processes  int       `json:"processes"`
+}
+
+type ProcessRef struct {
+	PID  int    `json:"pid"`
+	Name string `json:"name"`
+}
+
+// A bound port, the owners on either side are empty when it was opened or closed
+type PortChange struct {
+	Proto  string       `json:"proto"`
+	Addr   string       `json:"addr"`
+	Port   int          `json:"port"`
+	Before []ProcessRef `json:"before"`
+	After  []ProcessRef `json:"after"`
+}
+
+// Established connections of a process found in both snapshots
+type ConnectionDelta struct {
+	PID    int    `json:"pid"`
+	Name   string `json:"name"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Delta  int    `json:"delta"`
+}
+
+func (d Diff) Empty() bool {
+	return len(d.Appeared) == 0 && len(d.Gone) == 0 &&
+		len(d.PortsOpened) == 0 && len(d.PortsClosed) == 0 && len(d.PortsMoved) == 0 &&
+		len(d.Connections) == 0
+}
+
+type portKey struct {
+	proto, addr string
+	port        int
+}
+
+// Compare reports what changed from a to b. A PID reused by another program
+// counts as one process gone and another one appeared. Snapshots only hold
+// processes with sockets, so one closing its last socket is gone too
+func Compare(a, b Snapshot) Diff {
+	d := Diff{
+		Before:      a.meta(),
+		After:       b.meta(),
+		Appeared:    []ProcessRef{},
+		Gone:        []ProcessRef{},
+		PortsOpened: []PortChange{},
+		PortsClosed: []PortChange{},
+		PortsMoved:  []PortChange{},
+		Connections: []ConnectionDelta{},
+	}
+
+	before := byPID(a)
+	after := byPID(b)
+	for _, p := range a.Processes {
+		if q, ok := after[p.PID]; !ok || !sameProcess(p, q) {
+			d.Gone = append(d.Gone, p.ref())
+		}
+	}
+	for _, q := range b.Processes {
+		p, ok := before[q.PID]
+		if !ok || !sameProcess(p, q) {
+			d.Appeared = append(d.Appeared, q.ref())
+			continue
+		}
+		was, is := establishedCount(p.Sockets), establishedCount(q.Sockets)
+		if was != is {
+			d.Connections = append(d.Connections, ConnectionDelta{PID: q.PID, Name: q.Name, Before: was, After: is, Delta: is - was})
+		}
+	}
+
+	portsBefore, portsAfter := boundPorts(a), boundPorts(b)
+	for key, owners := range portsBefore {
+		now, ok := portsAfter[key]
+		switch {
+		case !ok:
+			d.PortsClosed = append(d.PortsClosed, key.change(owners, []ProcessRef{}))
+		case !slices.Equal(owners, now):
+			d.PortsMoved = append(d.PortsMoved, key.change(owners, now))
+		}
+	}
+	for key, owners := range portsAfter {
+		if _, ok := portsBefore[key]; !ok {
+			d.PortsOpened = append(d.PortsOpened, key.change([]ProcessRef{}, owners))
+		}
+	}
+	for _, changes := range [][]PortChange{d.PortsOpened, d.PortsClosed, d.PortsMoved} {
+		sortPortChanges(changes)
+	}
+	return d
+}
+
+func (s Snapshot) meta() Meta {
+	return Meta{CapturedAt: s.CapturedAt, Hostname: s.Hostname, Processes: len(s.Processes)}
+}
+
+func (p Process) ref() ProcessRef {
+	return ProcessRef{PID: p.PID, Name: p.Name}
+}
+
+func byPID(s Snapshot) map[int]Process {
+	out := make(map[int]Process, len(s.Processes))
+	for _, p := range s.Processes {
+		out[p.PID] = p
+	}
+	return out
+}
+
+// Start time tells a reused PID apart when both sides could read it
+func sameProcess(p, q Process) bool {
+	if p.Name != q.Name {
+		return false
+	}
+	if p.Resource != nil && q.Resource != nil {
+		return p.Resource.StartTimeTick == q.Resource.StartTimeTick
+	}
+	return true
+}
+
+func establishedCount(sockets []socket.Socket) int {
+	return socket.Aggregate(sockets).EstablishedCount
+}
+
+// Owners of every bound port, sorted by PID so two sides compare with slices.Equal
+func boundPorts(s Snapshot) map[portKey][]ProcessRef {
+	out := map[portKey][]ProcessRef{}
+	for _, p := range s.Processes {
+		for _, sock := range p.Sockets {
+			if !sock.IsBound() {
+				continue
+			}
+			key := portKey{proto: sock.Proto, addr: sock.Addr, port: sock.Port}
+			if !slices.Contains(out[key], p.ref()) {
+				out[key] = append(out[key], p.ref())
+			}
+		}
+	}
+	for _, owners := range out {
+		sort.Slice(owners, func(i, j int) bool {
+			return owners[i].PID < owners[j].PID
+		})
+	}
+	return out
+}
+
+func (k portKey) change(before, after []ProcessRef) PortChange {
+	return PortChange{Proto: k.proto, Addr: k.addr, Port: k.port, Before: before, After: after}
+}
+
+func sortPortChanges(changes []PortChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		a, b := changes[i], changes[j]
+		if a.Port != b.Port {
+			return a.Port < b.Port
+		}
+		if a.Proto != b.Proto {
+			return a.Proto < b.Proto
+		}
+		return a.Addr < b.Addr
+	})
+}
@@ -0,0 +1,155 @@
+package snapshot
+
+import (
+	"fmt"
+	"netps/internal/process"
+	"netps/internal/socket"
+	"slices"
+	"testing"
+)
+
+func proc(pid int, name string, startTick uint64, sockets ...socket.Socket) Process {
+	return Process{
+		PID:      pid,
+		Name:     name,
+		Resource: &process.ProcessResource{StartTimeTick: startTick},
+		Sockets:  sockets,
+	}
+}
+
+func listen(proto, addr string, port int) socket.Socket {
+	return socket.Socket{Proto: proto, Addr: addr, Port: port, State: socket.StateListen}
+}
+
+func established(port int) socket.Socket {
+	return socket.Socket{Proto: socket.ProtoTCP, Addr: "10.0.0.5", Port: port, RemoteAddr: "10.0.0.9", RemotePort: 51202, State: socket.StateEstablished}
+}
+
+// one line per change so a case reads as the diff it expects
+func summarize(d Diff) []string {
+	out := []string{}
+	for _, p := range d.Gone {
+		out = append(out, fmt.Sprintf("gone %s(%d)", p.Name, p.PID))
+	}
+	for _, p := range d.Appeared {
+		out = append(out, fmt.Sprintf("appeared %s(%d)", p.Name, p.PID))
+	}
+	for _, kind := range []struct {
+		name    string
+		changes []PortChange
+	}{{"opened", d.PortsOpened}, {"closed", d.PortsClosed}, {"moved", d.PortsMoved}} {
+		for _, c := range kind.changes {
+			out = append(out, fmt.Sprintf("%s %s %s:%d %v→%v", kind.name, c.Proto, c.Addr, c.Port, refs(c.Before), refs(c.After)))
+		}
+	}
+	for _, c := range d.Connections {
+		out = append(out, fmt.Sprintf("connections %s(%d) %d→%d", c.Name, c.PID, c.Before, c.After))
+	}
+	return out
+}
+
+func refs(owners []ProcessRef) []string {
+	out := []string{}
+	for _, r := range owners {
+		out = append(out, fmt.Sprintf("%s(%d)", r.Name, r.PID))
+	}
+	return out
+}
+
+func TestCompare(t *testing.T) {
+	http := listen(socket.ProtoTCP, "0.0.0.0", 80)
+	dns := listen(socket.ProtoUDP, "127.0.0.53", 53)
+	dns.State = socket.StateClose
+
+	cases := []struct {
+		name   string
+		before []Process
+		after  []Process
+		want   []string
+	}{
+		{
+			name:   "unchanged",
+			before: []Process{proc(812, "nginx", 100, http)},
+			after:  []Process{proc(812, "nginx", 100, http)},
+			want:   []string{},
+		},
+		{
+			name:   "port opened",
+			before: []Process{proc(812, "nginx", 100)},
+			after:  []Process{proc(812, "nginx", 100, http)},
+			want:   []string{"opened tcp 0.0.0.0:80 []→[nginx(812)]"},
+		},
+		{
+			name:   "port closed",
+			before: []Process{proc(812, "nginx", 100, http, dns)},
+			after:  []Process{proc(812, "nginx", 100, dns)},
+			want:   []string{"closed tcp 0.0.0.0:80 [nginx(812)]→[]"},
+		},
+		{
+			name:   "process started",
+			before: []Process{},
+			after:  []Process{proc(930, "dnsmasq", 300, dns)},
+			want:   []string{"appeared dnsmasq(930)", "opened udp 127.0.0.53:53 []→[dnsmasq(930)]"},
+		},
+		{
+			name:   "connection state change",
+			before: []Process{proc(812, "nginx", 100, http)},
+			after:  []Process{proc(812, "nginx", 100, http, established(80), established(80))},
+			want:   []string{"connections nginx(812) 0→2"},
+		},
+		{
+			name:   "port moves to another process",
+			before: []Process{proc(812, "nginx", 100, http)},
+			after:  []Process{proc(900, "caddy", 400, http)},
+			want:   []string{"gone nginx(812)", "appeared caddy(900)", "moved tcp 0.0.0.0:80 [nginx(812)]→[caddy(900)]"},
+		},
+		{
+			name:   "pid reused by another program",
+			before: []Process{proc(812, "nginx", 100, http)},
+			after:  []Process{proc(812, "caddy", 400, http)},
+			want:   []string{"gone nginx(812)", "appeared caddy(812)", "moved tcp 0.0.0.0:80 [nginx(812)]→[caddy(812)]"},
+		},
+		{
+			name:   "pid reused by the same program",
+			before: []Process{proc(812, "nginx", 100, http)},
+			after:  []Process{proc(812, "nginx", 500)},
+			want:   []string{"gone nginx(812)", "appeared nginx(812)", "closed tcp 0.0.0.0:80 [nginx(812)]→[]"},
+		},
+		{
+			name:   "start time unreadable on one side",
+			before: []Process{proc(812, "nginx", 100, http)},
+			after:  []Process{{PID: 812, Name: "nginx", ResourceErr: "permission denied", Sockets: []socket.Socket{http}}},
+			want:   []string{},
+		},
+		{
+			name:   "forked worker",
+			before: []Process{proc(812, "nginx", 100, http)},
+			after:  []Process{proc(812, "nginx", 100, http), proc(813, "nginx", 200, http)},
+			want:   []string{"appeared nginx(813)", "moved tcp 0.0.0.0:80 [nginx(812)]→[nginx(812) nginx(813)]"},
+		},
+		{
+			name:   "forked worker exits",
+			before: []Process{proc(813, "nginx", 200, http), proc(812, "nginx", 100, http)},
+			after:  []Process{proc(812, "nginx", 100, http)},
+			want:   []string{"gone nginx(813)", "moved tcp 0.0.0.0:80 [nginx(812) nginx(813)]→[nginx(812)]"},
+		},
+		{
+			// snapshots only hold processes with sockets
+			name:   "last socket closed",
+			before: []Process{proc(930, "dnsmasq", 300, dns)},
+			after:  []Process{},
+			want:   []string{"gone dnsmasq(930)", "closed udp 127.0.0.53:53 [dnsmasq(930)]→[]"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := Compare(Snapshot{Processes: c.before}, Snapshot{Processes: c.after})
+			if got := summarize(d); !slices.Equal(got, c.want) {
+				t.Errorf("Compare() = %q, want %q", got, c.want)
+			}
+			if d.Empty() != (len(c.want) == 0) {
+				t.Errorf("Empty() = %v with %d changes", d.Empty(), len(c.want))
+			}
+		})
+	}
+}
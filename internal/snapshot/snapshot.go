@@ -0,0 +1,215 @@
+// Package snapshot captures the process and socket state to a versioned archive
+// and serves it back through the same ports as the live /proc adapter
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"netps/internal/process"
+	"netps/internal/procfs"
+	"netps/internal/socket"
+	"os"
+	"sort"
+	"syscall"
+	"time"
+)
+
+// Version is bumped whenever a field changes meaning, readers refuse newer archives
+const Version = 1
+
+const (
+	documentName = "snapshot.json"
+	rawPrefix    = "raw"
+)
+
+type Snapshot struct {
+	Version    int       `json:"version"`
+	CapturedAt time.Time `json:"captured_at"`
+	Hostname   string    `json:"hostname"`
+	ClockTick  int64     `json:"clock_tick"`
+	PageSize   int64     `json:"page_size"`
+	UpTime     float64   `json:"uptime_seconds"`
+	Processes  []Process `json:"processes"`
+}
+
+// Raw source data, before the service turns ticks and pages into durations and bytes.
+// A section that could not be read keeps its error message instead, and whether the
+// process had exited by then so a replay can tell "gone" apart from any other failure
+type Process struct {
+	PID          int                      `json:"pid"`
+	Name         string                   `json:"name"`
+	Detail       *process.ProcessDetail   `json:"detail,omitempty"`
+	DetailErr    string                   `json:"detail_error,omitempty"`
+	DetailGone   bool                     `json:"detail_gone,omitempty"`
+	Resource     *process.ProcessResource `json:"resource,omitempty"`
+	ResourceErr  string                   `json:"resource_error,omitempty"`
+	ResourceGone bool                     `json:"resource_gone,omitempty"`
+	User         *process.ProcessUser     `json:"user,omitempty"`
+	UserErr      string                   `json:"user_error,omitempty"`
+	UserGone     bool                     `json:"user_gone,omitempty"`
+	Sockets      []socket.Socket          `json:"sockets"`
+}
+
+type Sources struct {
+	Sockets   socket.Socketsource
+	Name      process.NameSource
+	Detail    process.DetailSource
+	Resource  process.ResourceSource
+	User      process.UserSource
+	ClockTick process.ClockTickSource
+	PageSize  process.PageSizeSource
+	UpTime    process.UpTimeSource
+}
+
+// Capture reads every process holding a socket, the others are left out. Only the socket walk is required,
+// a process that exits halfway is kept with the errors of the sections it lost
+func Capture(ctx context.Context, src Sources) (Snapshot, error) {
+	running, err := src.Sockets.RunningSockets(ctx)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snap := Snapshot{Version: Version, CapturedAt: time.Now().UTC()}
+	snap.Hostname, _ = os.Hostname()
+	if snap.ClockTick, err = src.ClockTick.ClockTick(ctx); err != nil {
+		return Snapshot{}, err
+	}
+	if snap.PageSize, err = src.PageSize.PageSize(ctx); err != nil {
+		return Snapshot{}, err
+	}
+	if snap.UpTime, err = src.UpTime.UpTime(ctx); err != nil {
+		return Snapshot{}, err
+	}
+
+	for pid, sockets := range running {
+		name, err := src.Name.Name(ctx, pid)
+		if err != nil {
+			continue // exited between the fd walk and now
+		}
+		p := Process{PID: pid, Name: name, Sockets: sockets}
+		if detail, err := src.Detail.Detail(ctx, pid); err != nil {
+			p.DetailErr, p.DetailGone = err.Error(), isGone(err)
+		} else {
+			p.Detail = &detail
+		}
+		if resource, err := src.Resource.Resource(ctx, pid); err != nil {
+			p.ResourceErr, p.ResourceGone = err.Error(), isGone(err)
+		} else {
+			p.Resource = &resource
+		}
+		if user, err := src.User.User(ctx, pid); err != nil {
+			p.UserErr, p.UserGone = err.Error(), isGone(err)
+		} else {
+			p.User = &user
+		}
+		snap.Processes = append(snap.Processes, p)
+	}
+	sort.Slice(snap.Processes, func(i, j int) bool {
+		return snap.Processes[i].PID < snap.Processes[j].PID
+	})
+	return snap, nil
+}
+
+// The ways the ports report an exited process, see process.Service.GetProcessResource
+func isGone(err error) bool {
+	return errors.Is(err, process.ErrProcessGone) || errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ESRCH)
+}
+
+// PIDs of the captured processes, in order
+func (s Snapshot) PIDs() []int {
+	pids := make([]int, 0, len(s.Processes))
+	for _, p := range s.Processes {
+		pids = append(pids, p.PID)
+	}
+	return pids
+}
+
+// WriteArchive writes a gzipped tar holding snapshot.json, then the raw files under raw/
+func WriteArchive(w io.Writer, snap Snapshot, raw []procfs.RawFile) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	doc, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, documentName, doc, snap.CapturedAt); err != nil {
+		return err
+	}
+	for _, f := range raw {
+		name := rawPrefix + f.Path
+		if f.Link != "" {
+			err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: f.Link, Mode: 0o777, ModTime: snap.CapturedAt})
+		} else {
+			err = writeTarFile(tw, name, f.Data, snap.CapturedAt)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// ReadArchive returns the snapshot of an archive, raw files are not needed to replay it
+func ReadArchive(r io.Reader) (Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("not a netps snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return Snapshot{}, fmt.Errorf("not a netps snapshot: no %s", documentName)
+		}
+		if err != nil {
+			return Snapshot{}, err
+		}
+		if hdr.Name != documentName {
+			continue
+		}
+
+		var snap Snapshot
+		if err := json.NewDecoder(tr).Decode(&snap); err != nil {
+			return Snapshot{}, fmt.Errorf("invalid %s: %w", documentName, err)
+		}
+		if snap.Version < 1 || snap.Version > Version {
+			return Snapshot{}, fmt.Errorf("snapshot version %d is not supported, this build reads up to %d", snap.Version, Version)
+		}
+		// the service divides by both when turning ticks and pages into durations and bytes
+		if snap.ClockTick <= 0 || snap.PageSize <= 0 {
+			return Snapshot{}, fmt.Errorf("invalid %s: clock_tick %d and page_size %d must be positive", documentName, snap.ClockTick, snap.PageSize)
+		}
+		return snap, nil
+	}
+}
+
+func ReadFile(path string) (Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	defer f.Close()
+	return ReadArchive(f)
+}
@@ -0,0 +1,33 @@
+package snapshot
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadArchiveSystemValues(t *testing.T) {
+	cases := []struct {
+		name                string
+		clockTick, pageSize int64
+		err                 string
+	}{
+		{"valid", 100, 4096, ""},
+		{"clock tick missing", 0, 4096, "clock_tick 0"},
+		{"negative clock tick", -100, 4096, "clock_tick -100"},
+		{"page size missing", 100, 0, "page_size 0"},
+	}
+	for _, c := range cases {
+		var archive bytes.Buffer
+		if err := WriteArchive(&archive, Snapshot{Version: Version, ClockTick: c.clockTick, PageSize: c.pageSize}, nil); err != nil {
+			t.Fatal(err)
+		}
+		_, err := ReadArchive(&archive)
+		switch {
+		case c.err == "" && err != nil:
+			t.Errorf("%s: ReadArchive() = %v", c.name, err)
+		case c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)):
+			t.Errorf("%s: ReadArchive() = %v, want an error about %s", c.name, err, c.err)
+		}
+	}
+}
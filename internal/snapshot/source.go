@@ -0,0 +1,145 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"netps/internal/process"
+	"netps/internal/socket"
+	"slices"
+	"syscall"
+)
+
+// ErrReadOnly is returned for anything that would act on the host, e.g. signals
+var ErrReadOnly = errors.New("replaying a snapshot, nothing is sent to the host")
+
+// Source implements the process and socket ports on top of a snapshot.
+// A PID missing from the snapshot reads as an exited process
+type Source struct {
+	snap  Snapshot
+	byPID map[int]Process
+}
+
+func NewSource(snap Snapshot) *Source {
+	byPID := make(map[int]Process, len(snap.Processes))
+	for _, p := range snap.Processes {
+		byPID[p.PID] = p
+	}
+	return &Source{snap: snap, byPID: byPID}
+}
+
+// A section error read back from the archive, a process that had exited unwraps to process.ErrProcessGone
+type sectionError struct {
+	msg  string
+	gone bool
+}
+
+func (e sectionError) Error() string {
+	return e.msg
+}
+
+func (e sectionError) Unwrap() error {
+	if e.gone {
+		return process.ErrProcessGone
+	}
+	return nil
+}
+
+func (s *Source) lookup(pid int) (Process, error) {
+	p, ok := s.byPID[pid]
+	if !ok {
+		return Process{}, fmt.Errorf("pid %d not in snapshot: %w", pid, fs.ErrNotExist)
+	}
+	return p, nil
+}
+
+// Same composition as the /proc adapter, so summaries match what the live list showed
+func (s *Source) ListRunnings(ctx context.Context) ([]process.ProcessSummary, error) {
+	out := []process.ProcessSummary{}
+	for _, p := range s.snap.Processes {
+		summary := process.NewSummary(p.PID, p.Name).
+			WithAggregatedSockets(p.Sockets).
+			WithFilteredListenPorts(p.Sockets).
+			WithProtocols(p.Sockets)
+		out = append(out, *summary)
+	}
+	return out, nil
+}
+
+func (s *Source) Name(ctx context.Context, pid int) (string, error) {
+	p, err := s.lookup(pid)
+	return p.Name, err
+}
+
+func (s *Source) Detail(ctx context.Context, pid int) (process.ProcessDetail, error) {
+	p, err := s.lookup(pid)
+	if err != nil {
+		return process.ProcessDetail{}, err
+	}
+	if p.Detail == nil {
+		return process.ProcessDetail{}, sectionError{msg: p.DetailErr, gone: p.DetailGone}
+	}
+	return *p.Detail, nil
+}
+
+func (s *Source) Resource(ctx context.Context, pid int) (process.ProcessResource, error) {
+	p, err := s.lookup(pid)
+	if err != nil {
+		return process.ProcessResource{}, err
+	}
+	if p.Resource == nil {
+		return process.ProcessResource{}, sectionError{msg: p.ResourceErr, gone: p.ResourceGone}
+	}
+	return *p.Resource, nil
+}
+
+func (s *Source) User(ctx context.Context, pid int) (process.ProcessUser, error) {
+	p, err := s.lookup(pid)
+	if err != nil {
+		return process.ProcessUser{}, err
+	}
+	if p.User == nil {
+		return process.ProcessUser{}, sectionError{msg: p.UserErr, gone: p.UserGone}
+	}
+	return *p.User, nil
+}
+
+func (s *Source) ClockTick(ctx context.Context) (int64, error) {
+	return s.snap.ClockTick, nil
+}
+
+func (s *Source) PageSize(ctx context.Context) (int64, error) {
+	return s.snap.PageSize, nil
+}
+
+// Uptime at capture time, elapsed times read as they were then
+func (s *Source) UpTime(ctx context.Context) (float64, error) {
+	return s.snap.UpTime, nil
+}
+
+func (s *Source) Signal(ctx context.Context, pid int, sig syscall.Signal) error {
+	return ErrReadOnly
+}
+
+func (s *Source) SocketsByStates(ctx context.Context, pid int, states []socket.SocketState) ([]socket.Socket, error) {
+	p, err := s.lookup(pid)
+	if err != nil {
+		return []socket.Socket{}, err
+	}
+	out := []socket.Socket{}
+	for _, sock := range p.Sockets {
+		if slices.Contains(states, sock.State) {
+			out = append(out, sock)
+		}
+	}
+	return out, nil
+}
+
+func (s *Source) RunningSockets(ctx context.Context) (map[int][]socket.Socket, error) {
+	out := make(map[int][]socket.Socket, len(s.snap.Processes))
+	for _, p := range s.snap.Processes {
+		out[p.PID] = p.Sockets
+	}
+	return out, nil
+}
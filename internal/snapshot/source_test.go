@@ -0,0 +1,90 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"netps/internal/process"
+	"netps/internal/socket"
+	"syscall"
+	"testing"
+)
+
+// exiting serves a socket walk that saw pid 812, then fails each section the way /proc does
+type exiting struct {
+	*Source
+	detail, resource, user error
+}
+
+func (e exiting) Detail(ctx context.Context, pid int) (process.ProcessDetail, error) {
+	return process.ProcessDetail{}, e.detail
+}
+
+func (e exiting) Resource(ctx context.Context, pid int) (process.ProcessResource, error) {
+	return process.ProcessResource{}, e.resource
+}
+
+func (e exiting) User(ctx context.Context, pid int) (process.ProcessUser, error) {
+	return process.ProcessUser{}, e.user
+}
+
+func TestReplayKeepsProcessGone(t *testing.T) {
+	live := exiting{
+		Source: NewSource(Snapshot{ClockTick: 100, PageSize: 4096, Processes: []Process{{
+			PID:     812,
+			Name:    "nginx",
+			Sockets: []socket.Socket{{Proto: socket.ProtoTCP, Addr: "0.0.0.0", Port: 80, State: socket.StateListen, Inode: 1}},
+		}}}),
+		detail:   fmt.Errorf("readlink /proc/812/exe: %w", fs.ErrNotExist),
+		resource: fmt.Errorf("read /proc/812/stat: %w", syscall.ESRCH),
+		user:     fmt.Errorf("open /proc/812/status: %w", fs.ErrPermission),
+	}
+	snap, err := Capture(context.Background(), Sources{
+		Sockets: live, Name: live, Detail: live, Resource: live, User: live,
+		ClockTick: live, PageSize: live, UpTime: live,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var archive bytes.Buffer
+	if err := WriteArchive(&archive, snap, nil); err != nil {
+		t.Fatal(err)
+	}
+	replayed, err := ReadArchive(&archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := NewSource(replayed)
+	ctx := context.Background()
+
+	_, detailErr := source.Detail(ctx, 812)
+	_, resourceErr := source.Resource(ctx, 812)
+	_, userErr := source.User(ctx, 812)
+	cases := []struct {
+		section  string
+		err      error
+		original error
+		gone     bool
+	}{
+		{"detail", detailErr, live.detail, true},
+		{"resource", resourceErr, live.resource, true},
+		{"user", userErr, live.user, false},
+	}
+	for _, c := range cases {
+		if c.err == nil || c.err.Error() != c.original.Error() {
+			t.Errorf("%s: replayed %v, want the captured message %q", c.section, c.err, c.original)
+		}
+		if got := errors.Is(c.err, process.ErrProcessGone); got != c.gone {
+			t.Errorf("%s: errors.Is(%v, ErrProcessGone) = %v, want %v", c.section, c.err, got, c.gone)
+		}
+	}
+
+	// what the detail screen checks to switch to the process gone state
+	processService := process.NewProcessService(process.Config{Resource: source, Clocktick: source, PageSize: source, UpTime: source})
+	if _, err := processService.GetProcessResource(ctx, 812); !errors.Is(err, process.ErrProcessGone) {
+		t.Errorf("GetProcessResource() = %v, want ErrProcessGone", err)
+	}
+}
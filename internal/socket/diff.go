@@ -0,0 +1,72 @@
+package socket
+
+import "sort"
+
+type EventKind string
+
+const (
+	EventClosed       EventKind = "closed"
+	EventStateChanged EventKind = "state"
+	EventOpened       EventKind = "opened"
+)
+
+// A socket appearing, disappearing or changing state for one of its owners.
+// A socket passed from one process to another is closed for the first and opened for the second
+type Event struct {
+	Kind      EventKind
+	PID       int
+	Socket    Socket
+	PrevState SocketState // only set for EventStateChanged
+}
+
+var eventRank = map[EventKind]int{EventClosed: 0, EventStateChanged: 1, EventOpened: 2}
+
+// Diff compares two snapshots of sockets by owner PID. Events come closed first,
+// then state changes, then opened, each group by PID, so a port moving between
+// processes reads as released then taken
+func Diff(prev, next map[int][]Socket) []Event {
+	events := []Event{}
+	for pid, sockets := range prev {
+		after := byInode(next[pid])
+		for _, s := range sockets {
+			n, ok := after[s.Inode]
+			switch {
+			case !ok:
+				events = append(events, Event{Kind: EventClosed, PID: pid, Socket: s})
+			case n.State != s.State:
+				events = append(events, Event{Kind: EventStateChanged, PID: pid, Socket: n, PrevState: s.State})
+			}
+		}
+	}
+	for pid, sockets := range next {
+		before := byInode(prev[pid])
+		for _, s := range sockets {
+			if _, ok := before[s.Inode]; !ok {
+				events = append(events, Event{Kind: EventOpened, PID: pid, Socket: s})
+			}
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if eventRank[a.Kind] != eventRank[b.Kind] {
+			return eventRank[a.Kind] < eventRank[b.Kind]
+		}
+		if a.PID != b.PID {
+			return a.PID < b.PID
+		}
+		if a.Socket.Port != b.Socket.Port {
+			return a.Socket.Port < b.Socket.Port
+		}
+		return a.Socket.Inode < b.Socket.Inode
+	})
+	return events
+}
+
+func byInode(sockets []Socket) map[uint64]Socket {
+	out := make(map[uint64]Socket, len(sockets))
+	for _, s := range sockets {
+		out[s.Inode] = s
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+package socket
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	listener := Socket{Proto: ProtoTCP, Addr: "0.0.0.0", Port: 80, State: StateListen, Inode: 100}
+	conn := Socket{Proto: ProtoTCP, Addr: "10.0.0.5", Port: 80, RemoteAddr: "10.0.0.9", RemotePort: 51202, State: StateEstablished, Inode: 200}
+	closing := conn
+	closing.State = StateCloseWait
+	rebound := listener
+	rebound.Inode = 101
+
+	cases := []struct {
+		name       string
+		prev, next map[int][]Socket
+		want       []string
+	}{
+		{
+			name: "unchanged",
+			prev: map[int][]Socket{812: {listener, conn}},
+			next: map[int][]Socket{812: {listener, conn}},
+			want: []string{},
+		},
+		{
+			name: "opened",
+			prev: map[int][]Socket{812: {listener}},
+			next: map[int][]Socket{812: {listener, conn}},
+			want: []string{"opened 812 200"},
+		},
+		{
+			name: "closed",
+			prev: map[int][]Socket{812: {listener, conn}},
+			next: map[int][]Socket{812: {listener}},
+			want: []string{"closed 812 200"},
+		},
+		{
+			name: "state change",
+			prev: map[int][]Socket{812: {conn}},
+			next: map[int][]Socket{812: {closing}},
+			want: []string{"state 812 200 ESTABLISHED→CLOSE_WAIT"},
+		},
+		{
+			name: "process exited",
+			prev: map[int][]Socket{812: {listener, conn}},
+			next: map[int][]Socket{},
+			want: []string{"closed 812 100", "closed 812 200"},
+		},
+		{
+			// the old listener is released before the new one is taken, even though 900 sorts after 812
+			name: "port moves to another process",
+			prev: map[int][]Socket{900: {listener}},
+			next: map[int][]Socket{812: {rebound}},
+			want: []string{"closed 900 100", "opened 812 101"},
+		},
+		{
+			// inodes are never shared between the two programs, the reused PID needs no start time here
+			name: "pid reused by another program",
+			prev: map[int][]Socket{812: {listener}},
+			next: map[int][]Socket{812: {rebound}},
+			want: []string{"closed 812 100", "opened 812 101"},
+		},
+		{
+			name: "socket passed to another process",
+			prev: map[int][]Socket{812: {listener}},
+			next: map[int][]Socket{813: {listener}},
+			want: []string{"closed 812 100", "opened 813 100"},
+		},
+		{
+			name: "forked worker inherits the listener",
+			prev: map[int][]Socket{812: {listener}},
+			next: map[int][]Socket{812: {listener}, 813: {listener}},
+			want: []string{"opened 813 100"},
+		},
+		{
+			name: "forked worker exits, the master keeps the listener",
+			prev: map[int][]Socket{812: {listener}, 813: {listener}, 814: {listener}},
+			next: map[int][]Socket{812: {listener}, 814: {listener}},
+			want: []string{"closed 813 100"},
+		},
+		{
+			name: "grouped by kind then pid",
+			prev: map[int][]Socket{2: {conn}, 1: {listener}},
+			next: map[int][]Socket{2: {closing}, 3: {rebound}, 1: {}},
+			want: []string{"closed 1 100", "state 2 200 ESTABLISHED→CLOSE_WAIT", "opened 3 101"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := []string{}
+			for _, e := range Diff(c.prev, c.next) {
+				line := fmt.Sprintf("%s %d %d", e.Kind, e.PID, e.Socket.Inode)
+				if e.Kind == EventStateChanged {
+					line += fmt.Sprintf(" %s→%s", e.PrevState, e.Socket.State)
+				}
+				got = append(got, line)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("Diff() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package socket
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PortQuery selects the sockets bound to a port, an empty Proto or Addr matches any.
+// Proto without the "6" suffix covers both families, e.g. "tcp" matches tcp6 too
+type PortQuery struct {
+	Port  int
+	Proto string
+	Addr  string
+}
+
+var lookupProtos = []string{ProtoTCP, ProtoTCP6, ProtoUDP, ProtoUDP6, ProtoUDPLite, ProtoUDPLite6, ProtoSCTP, ProtoSCTP6}
+
+// ParsePortQuery reads "[proto] [addr:]port", e.g. "8080", ":8080", "udp 53" or "tcp [::1]:8080"
+func ParsePortQuery(s string) (PortQuery, error) {
+	fields := strings.Fields(s)
+	q := PortQuery{}
+	switch len(fields) {
+	case 1:
+	case 2:
+		q.Proto = strings.ToLower(fields[0])
+		fields = fields[1:]
+	default:
+		return PortQuery{}, fmt.Errorf("expected [proto] [addr:]port, got %q", s)
+	}
+
+	endpoint := fields[0]
+	portText := endpoint
+	if strings.Contains(endpoint, ":") {
+		host, port, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return PortQuery{}, fmt.Errorf("invalid address %q: %w", endpoint, err)
+		}
+		q.Addr, portText = host, port
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return PortQuery{}, fmt.Errorf("invalid port %q", portText)
+	}
+	q.Port = port
+	return q, q.Validate()
+}
+
+func (q PortQuery) Validate() error {
+	if q.Port <= 0 || q.Port > 65535 {
+		return fmt.Errorf("port %d out of range", q.Port)
+	}
+	if q.Proto != "" && !slices.Contains(lookupProtos, q.Proto) {
+		return fmt.Errorf("unknown protocol %q, expected one of %s", q.Proto, strings.Join(lookupProtos, ", "))
+	}
+	if q.Addr != "" && net.ParseIP(q.Addr) == nil {
+		return fmt.Errorf("invalid address %q", q.Addr)
+	}
+	return nil
+}
+
+// Matches reports whether s holds the queried port. A wildcard listener matches any
+// address of its family, and "::" also matches IPv4 since it is dual stack by default
+func (q PortQuery) Matches(s Socket) bool {
+	if !s.IsBound() || s.Port != q.Port {
+		return false
+	}
+	if q.Proto != "" && s.Proto != q.Proto && strings.TrimSuffix(s.Proto, "6") != q.Proto {
+		return false
+	}
+	if q.Addr == "" {
+		return true
+	}
+	want, have := net.ParseIP(q.Addr), net.ParseIP(s.Addr)
+	if want == nil || have == nil {
+		return false
+	}
+	if have.Equal(want) {
+		return true
+	}
+	if !have.IsUnspecified() {
+		return false
+	}
+	return have.To4() == nil || want.To4() != nil
+}
+
+func (q PortQuery) String() string {
+	endpoint := strconv.Itoa(q.Port)
+	if q.Addr != "" {
+		endpoint = net.JoinHostPort(q.Addr, endpoint)
+	}
+	if q.Proto != "" {
+		return q.Proto + " " + endpoint
+	}
+	return endpoint
+}
@@ -0,0 +1,77 @@
+package socket
+
+import "testing"
+
+func TestParsePortQuery(t *testing.T) {
+	cases := []struct {
+		in   string
+		want PortQuery
+		ok   bool
+	}{
+		{"8080", PortQuery{Port: 8080}, true},
+		{":8080", PortQuery{Port: 8080}, true},
+		{"udp 53", PortQuery{Port: 53, Proto: ProtoUDP}, true},
+		{"TCP 80", PortQuery{Port: 80, Proto: ProtoTCP}, true},
+		{"tcp6 [::1]:8080", PortQuery{Port: 8080, Proto: ProtoTCP6, Addr: "::1"}, true},
+		{"127.0.0.1:22", PortQuery{Port: 22, Addr: "127.0.0.1"}, true},
+		{"[::]:443", PortQuery{Port: 443, Addr: "::"}, true},
+		{"", PortQuery{}, false},
+		{"tcp udp 80", PortQuery{}, false},
+		{"0", PortQuery{}, false},
+		{"65536", PortQuery{}, false},
+		{"http", PortQuery{}, false},
+		{"unix 80", PortQuery{}, false},
+		{"raw 1", PortQuery{}, false},
+		{"::1:8080", PortQuery{}, false},
+		{"localhost:80", PortQuery{}, false},
+	}
+	for _, c := range cases {
+		got, err := ParsePortQuery(c.in)
+		if (err == nil) != c.ok {
+			t.Errorf("ParsePortQuery(%q) error = %v, want ok %v", c.in, err, c.ok)
+			continue
+		}
+		if c.ok && got != c.want {
+			t.Errorf("ParsePortQuery(%q) = %+v, want %+v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestPortQueryMatches(t *testing.T) {
+	listen := func(proto, addr string) Socket {
+		return Socket{Proto: proto, Addr: addr, Port: 8080, State: StateListen}
+	}
+	cases := []struct {
+		name  string
+		query PortQuery
+		sock  Socket
+		want  bool
+	}{
+		{"any address", PortQuery{Port: 8080}, listen(ProtoTCP, "127.0.0.1"), true},
+		{"other port", PortQuery{Port: 8081}, listen(ProtoTCP, "127.0.0.1"), false},
+		{"not bound", PortQuery{Port: 8080}, Socket{Proto: ProtoTCP, Addr: "127.0.0.1", Port: 8080, State: StateEstablished}, false},
+		{"udp is bound without listening", PortQuery{Port: 8080, Proto: ProtoUDP}, Socket{Proto: ProtoUDP, Addr: "0.0.0.0", Port: 8080, State: StateClose}, true},
+		{"raw icmp socket holds no port", PortQuery{Port: 1}, Socket{Proto: ProtoRaw, Addr: "0.0.0.0", Port: 1, State: StateClose}, false},
+		{"raw icmpv6 socket holds no port", PortQuery{Port: 58}, Socket{Proto: ProtoRaw6, Addr: "::", Port: 58, State: StateClose}, false},
+
+		{"same protocol", PortQuery{Port: 8080, Proto: ProtoTCP}, listen(ProtoTCP, "0.0.0.0"), true},
+		{"family-less protocol covers v6", PortQuery{Port: 8080, Proto: ProtoTCP}, listen(ProtoTCP6, "::"), true},
+		{"v6 protocol leaves v4 out", PortQuery{Port: 8080, Proto: ProtoTCP6}, listen(ProtoTCP, "0.0.0.0"), false},
+		{"other protocol", PortQuery{Port: 8080, Proto: ProtoUDP}, listen(ProtoTCP, "0.0.0.0"), false},
+
+		{"exact address", PortQuery{Port: 8080, Addr: "127.0.0.1"}, listen(ProtoTCP, "127.0.0.1"), true},
+		{"other address", PortQuery{Port: 8080, Addr: "127.0.0.1"}, listen(ProtoTCP, "10.0.0.5"), false},
+		{"v4 wildcard takes any v4", PortQuery{Port: 8080, Addr: "127.0.0.1"}, listen(ProtoTCP, "0.0.0.0"), true},
+		{"v4 wildcard leaves v6 out", PortQuery{Port: 8080, Addr: "::1"}, listen(ProtoTCP, "0.0.0.0"), false},
+		{"v6 wildcard takes any v6", PortQuery{Port: 8080, Addr: "::1"}, listen(ProtoTCP6, "::"), true},
+		{"v6 wildcard is dual stack", PortQuery{Port: 8080, Addr: "127.0.0.1"}, listen(ProtoTCP6, "::"), true},
+		{"v4-mapped listener", PortQuery{Port: 8080, Addr: "127.0.0.1"}, listen(ProtoTCP6, "::ffff:127.0.0.1"), true},
+		{"specific listener is not a wildcard", PortQuery{Port: 8080, Addr: "0.0.0.0"}, listen(ProtoTCP, "127.0.0.1"), false},
+		{"unparsable socket address", PortQuery{Port: 8080, Addr: "127.0.0.1"}, listen(ProtoTCP, ""), false},
+	}
+	for _, c := range cases {
+		if got := c.query.Matches(c.sock); got != c.want {
+			t.Errorf("%s: %v.Matches(%s %s) = %v, want %v", c.name, c.query, c.sock.Proto, c.sock.LocalEndpoint(), got, c.want)
+		}
+	}
+}
@@ -4,4 +4,5 @@ import "context"
 
 type Socketsource interface {
 	SocketsByStates(ctx context.Context, pid int, states []SocketState) ([]Socket, error)
+	RunningSockets(ctx context.Context) (map[int][]Socket, error)
 }
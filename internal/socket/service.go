@@ -1,6 +1,9 @@
 package socket
 
-import "context"
+import (
+	"context"
+	"sort"
+)
 
 type Service struct {
 	socket Socketsource
@@ -17,8 +20,48 @@ func NewService(
 func (s *Service) GetSocketsByStates(ctx context.Context, pid int, states []SocketState) ([]Socket, error) {
 	sockets, err := s.socket.SocketsByStates(ctx, pid, states)
 	if err != nil {
-		return []Socket{}, nil
+		return []Socket{}, err
 	}
 
 	return sockets, nil
 }
+
+// GetRunningSockets returns the sockets of every process, keyed by owner PID.
+// A socket held by several processes is listed under each of them
+func (s *Service) GetRunningSockets(ctx context.Context) (map[int][]Socket, error) {
+	return s.socket.RunningSockets(ctx)
+}
+
+// FindBound returns every socket matching the query once, each one listing all of its owners
+func (s *Service) FindBound(ctx context.Context, q PortQuery) ([]Socket, error) {
+	running, err := s.socket.RunningSockets(ctx)
+	if err != nil {
+		return []Socket{}, err
+	}
+
+	seen := map[uint64]bool{}
+	out := []Socket{}
+	for _, sockets := range running {
+		for _, sock := range sockets {
+			if seen[sock.Inode] || !q.Matches(sock) {
+				continue
+			}
+			seen[sock.Inode] = true
+			out = append(out, sock)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Proto != out[j].Proto {
+			return out[i].Proto < out[j].Proto
+		}
+		if out[i].Addr != out[j].Addr {
+			return out[i].Addr < out[j].Addr
+		}
+		if out[i].Port != out[j].Port {
+			return out[i].Port < out[j].Port
+		}
+		// SO_REUSEPORT lets several sockets hold the same address and port, the inode keeps them in a stable order
+		return out[i].Inode < out[j].Inode
+	})
+	return out, nil
+}
@@ -0,0 +1,47 @@
+package socket
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+type fakeSource map[int][]Socket
+
+func (f fakeSource) SocketsByStates(ctx context.Context, pid int, states []SocketState) ([]Socket, error) {
+	return f[pid], nil
+}
+
+func (f fakeSource) RunningSockets(ctx context.Context) (map[int][]Socket, error) {
+	return f, nil
+}
+
+func TestFindBound(t *testing.T) {
+	udp := func(inode uint64, pids ...int) Socket {
+		s := Socket{Proto: ProtoUDP, Addr: "0.0.0.0", Port: 53, State: StateClose, Inode: inode}
+		for _, pid := range pids {
+			s.Owners = append(s.Owners, Owner{PID: pid, FD: 3})
+		}
+		return s
+	}
+	shared := Socket{Proto: ProtoTCP6, Addr: "::", Port: 53, State: StateListen, Inode: 30, Owners: []Owner{{PID: 1, FD: 4}, {PID: 2, FD: 4}}}
+	source := fakeSource{
+		// two SO_REUSEPORT sockets on the same address, listed under their owners in no particular order
+		1: {udp(21, 1), shared, {Proto: ProtoTCP, Addr: "127.0.0.1", Port: 53, State: StateEstablished, Inode: 40}},
+		2: {shared, udp(20, 2)},
+		3: {{Proto: ProtoUDP, Addr: "127.0.0.53", Port: 53, State: StateClose, Inode: 10}},
+	}
+
+	got, err := NewService(source).FindBound(context.Background(), PortQuery{Port: 53})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inodes := []uint64{}
+	for _, s := range got {
+		inodes = append(inodes, s.Inode)
+	}
+	// proto, then address, then inode; the shared listener once and the established socket not at all
+	if want := []uint64{30, 20, 21, 10}; !slices.Equal(inodes, want) {
+		t.Errorf("FindBound(53) inodes %v, want %v", inodes, want)
+	}
+}
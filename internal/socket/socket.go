@@ -1,5 +1,12 @@
 package socket
 
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
 type SocketState string
 
 const (
@@ -16,19 +23,65 @@ const (
 	StateClosing     SocketState = "CLOSING"
 	StateNewSynRecv  SocketState = "NEW_SYN_RECV"
 	StateUnknown     SocketState = "UNKNOWN"
+
+	// unix domain socket states (SS_* in the kernel), listening and
+	// connected unix sockets map to StateListen and StateEstablished
+	StateUnconnected   SocketState = "UNCONNECTED"
+	StateConnecting    SocketState = "CONNECTING"
+	StateDisconnecting SocketState = "DISCONNECTING"
+)
+
+const (
+	ProtoTCP      = "tcp"
+	ProtoTCP6     = "tcp6"
+	ProtoUDP      = "udp"
+	ProtoUDP6     = "udp6"
+	ProtoUDPLite  = "udplite"
+	ProtoUDPLite6 = "udplite6"
+	ProtoRaw      = "raw"
+	ProtoRaw6     = "raw6"
+	ProtoSCTP     = "sctp"
+	ProtoSCTP6    = "sctp6"
+	ProtoPacket   = "packet"
+	ProtoUnix     = "unix"
 )
 
 type Socket struct {
-	Proto string
-	Addr  string
-	Port  int
-	State SocketState
+	Proto      string      `json:"proto"`
+	Addr       string      `json:"addr"`
+	Port       int         `json:"port"`
+	RemoteAddr string      `json:"remote_addr"`
+	RemotePort int         `json:"remote_port"`
+	State      SocketState `json:"state"`
+	Path       string      `json:"path,omitempty"` // unix only, abstract names start with "@", empty when unnamed
+	Type       string      `json:"type,omitempty"` // unix and packet only: stream, dgram, seqpacket or raw
+	UID        int         `json:"uid"`            // -1 when the source doesn't expose it
+	Inode      uint64      `json:"inode"`
+	TCPInfo    *TCPInfo    `json:"tcp_info,omitempty"` // only filled by the netlink backend, nil otherwise
+	Owners     []Owner     `json:"owners"`             // holders, inherited sockets have more than one
+}
+
+// A file descriptor referring to a socket inode
+type Owner struct {
+	PID int `json:"pid"`
+	FD  int `json:"fd"`
+}
+
+// Subset of the kernel's struct tcp_info
+type TCPInfo struct {
+	RTT              time.Duration `json:"rtt_ns"`
+	RTTVar           time.Duration `json:"rtt_var_ns"`
+	Retransmits      uint32        `json:"retransmits"`       // total retransmitted segments
+	CongestionWindow uint32        `json:"congestion_window"` // segments
+	BytesAcked       uint64        `json:"bytes_acked"`
+	BytesReceived    uint64        `json:"bytes_received"`
 }
 
 type AggregatedSockets struct {
 	EstablishedCount int
 	ListenCount      int
 	CloseCount       int
+	UnixCount        int
 }
 
 func NewSocketInfo(proto, addr string, port int, state SocketState) *Socket {
@@ -40,9 +93,114 @@ func NewSocketInfo(proto, addr string, port int, state SocketState) *Socket {
 	}
 }
 
+func (s Socket) IsUnix() bool {
+	return s.Proto == ProtoUnix
+}
+
+// IsPacket reports whether the socket is an AF_PACKET socket, Addr holds the
+// bound interface and Port the ethernet protocol
+func (s Socket) IsPacket() bool {
+	return s.Proto == ProtoPacket
+}
+
+// IsRaw reports whether the socket is a raw IP socket, Port holds the IP protocol
+// number (1 for ICMP, 58 for ICMPv6) rather than a port
+func (s Socket) IsRaw() bool {
+	return s.Proto == ProtoRaw || s.Proto == ProtoRaw6
+}
+
+// ProtoLabel is the protocol as shown to the user, unix sockets include their type
+func (s Socket) ProtoLabel() string {
+	if (s.IsUnix() || s.IsPacket()) && s.Type != "" {
+		return s.Proto + "/" + s.Type
+	}
+	return s.Proto
+}
+
+// OwnerPIDs returns the distinct PIDs holding the socket in the order they were found,
+// a process holding the same socket on several fds (dup) is listed once
+func (s Socket) OwnerPIDs() []int {
+	pids := []int{}
+	seen := map[int]bool{}
+	for _, o := range s.Owners {
+		if !seen[o.PID] {
+			pids = append(pids, o.PID)
+			seen[o.PID] = true
+		}
+	}
+	return pids
+}
+
+// IsShared reports whether more than one process holds the socket,
+// e.g. a listener inherited by prefork workers
+func (s Socket) IsShared() bool {
+	return len(s.OwnerPIDs()) > 1
+}
+
+// OtherOwnerPIDs returns the PIDs holding the socket besides pid
+func (s Socket) OtherOwnerPIDs(pid int) []int {
+	others := []int{}
+	for _, p := range s.OwnerPIDs() {
+		if p != pid {
+			others = append(others, p)
+		}
+	}
+	return others
+}
+
+// IsBound reports whether the socket holds its local port for incoming traffic:
+// listeners, and datagram sockets which have no listen state. Raw sockets hold no port
+func (s Socket) IsBound() bool {
+	if s.IsUnix() || s.IsPacket() || s.IsRaw() || s.Port == 0 {
+		return false
+	}
+	switch s.Proto {
+	case ProtoUDP, ProtoUDP6, ProtoUDPLite, ProtoUDPLite6:
+		return true
+	}
+	return s.State == StateListen
+}
+
+// HasRemote reports whether the socket is connected to a peer,
+// unconnected sockets keep the wildcard address and port 0
+func (s Socket) HasRemote() bool {
+	return s.RemotePort != 0
+}
+
+func (s Socket) LocalEndpoint() string {
+	return net.JoinHostPort(s.Addr, strconv.Itoa(s.Port))
+}
+
+func (s Socket) RemoteEndpoint() string {
+	return net.JoinHostPort(s.RemoteAddr, strconv.Itoa(s.RemotePort))
+}
+
+// Endpoints renders "local → remote" for connected sockets and only the local end otherwise
+func (s Socket) Endpoints() string {
+	if s.IsUnix() {
+		if s.Path == "" {
+			return "(unnamed)"
+		}
+		return s.Path
+	}
+	if s.IsPacket() {
+		return fmt.Sprintf("%s proto 0x%04x", s.Addr, s.Port)
+	}
+	if !s.HasRemote() {
+		return s.LocalEndpoint()
+	}
+	return s.LocalEndpoint() + " → " + s.RemoteEndpoint()
+}
+
 func Aggregate(socks []Socket) AggregatedSockets {
 	aggregated := AggregatedSockets{}
 	for _, socket := range socks {
+		// unix sockets are counted apart, they don't have ports and mixing
+		// them in would inflate the L/E/C counts of every dbus client
+		if socket.IsUnix() {
+			aggregated.UnixCount++
+			continue
+		}
 		switch socket.State {
 		case StateListen:
 			aggregated.ListenCount++
@@ -0,0 +1,30 @@
+package socket
+
+import "testing"
+
+func TestIsBound(t *testing.T) {
+	cases := []struct {
+		name string
+		sock Socket
+		want bool
+	}{
+		{"tcp listener", Socket{Proto: ProtoTCP, Port: 80, State: StateListen}, true},
+		{"tcp6 listener", Socket{Proto: ProtoTCP6, Port: 443, State: StateListen}, true},
+		{"sctp listener", Socket{Proto: ProtoSCTP, Port: 3868, State: StateListen}, true},
+		{"established tcp", Socket{Proto: ProtoTCP, Port: 80, State: StateEstablished}, false},
+		{"sctp association", Socket{Proto: ProtoSCTP, Port: 3868, State: StateEstablished}, false},
+		{"unconnected udp", Socket{Proto: ProtoUDP, Port: 53, State: StateClose}, true},
+		{"connected udp6", Socket{Proto: ProtoUDP6, Port: 546, State: StateEstablished}, true},
+		{"udplite", Socket{Proto: ProtoUDPLite, Port: 5000, State: StateClose}, true},
+		{"raw6 icmpv6", Socket{Proto: ProtoRaw6, Port: 58, State: StateClose}, false},
+		{"raw icmp", Socket{Proto: ProtoRaw, Port: 1, State: StateClose}, false},
+		{"udp on port 0", Socket{Proto: ProtoUDP, Port: 0, State: StateClose}, false},
+		{"unix listener", Socket{Proto: ProtoUnix, State: StateListen}, false},
+		{"packet", Socket{Proto: ProtoPacket, Port: 0x0003, State: StateUnconnected}, false},
+	}
+	for _, c := range cases {
+		if got := c.sock.IsBound(); got != c.want {
+			t.Errorf("%s: IsBound() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+// Clock schedules the timers of the screens: the auto-refresh tick and the blinking
+// text cursor. A scripted session swaps it for one where time does not pass
+type Clock interface {
+	Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd
+	CursorBlink() bool
+}
+
+// WallClock is the terminal's clock
+type WallClock struct{}
+
+func (WallClock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return tea.Tick(d, fn)
+}
+
+func (WallClock) CursorBlink() bool {
+	return true
+}
+
+// NewTextInput returns a text input whose cursor only blinks if the clock lets it,
+// bubbles schedules that timer itself
+func NewTextInput(clock Clock) textinput.Model {
+	input := textinput.New()
+	styles := input.Styles()
+	styles.Cursor.Blink = clock.CursorBlink()
+	input.SetStyles(styles)
+	return input
+}
@@ -10,10 +10,15 @@ const (
 	KeyR     KeyPress = "r"
 	KeyM     KeyPress = "m"
 	KeyF     KeyPress = "f"
+	KeyE     KeyPress = "e"
+	KeyV     KeyPress = "v"
+	KeyW     KeyPress = "w"
 	KeyO     KeyPress = "o"
+	KeyP     KeyPress = "p"
 	KeyEsc   KeyPress = "esc"
 	KeyDel   KeyPress = "delete"
 	KeyS     KeyPress = "s"
+	KeyY     KeyPress = "y"
 	KeyCtrlC KeyPress = "ctrl+c"
 	KeyUp    KeyPress = "up"
 	KeyDown  KeyPress = "down"
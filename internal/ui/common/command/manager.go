@@ -11,20 +11,27 @@ type CommandSource int
 type Context string
 
 const (
+	CommandApply          Command = "Apply"
 	CommandBack           Command = "Back"
+	CommandConfirm        Command = "Confirm"
 	CommandDismiss        Command = "Dismiss"
 	CommandExecute        Command = "Execute"
+	CommandExport         Command = "Export"
 	CommandFilter         Command = "Filter"
+	CommandFindPort       Command = "Find Port"
 	CommandInspect        Command = "Inspect"
 	CommandMove           Command = "Move"
 	CommandMultipleSelect Command = "Mult. Select"
 	CommandOrder          Command = "Order"
+	CommandPause          Command = "Pause"
 	CommandQuit           Command = "Quit"
 	CommandRetry          Command = "Retry"
+	CommandReverse        Command = "Reverse"
 	CommandScroll         Command = "Scroll"
 	CommandSelect         Command = "Select"
 	CommandSendSignal     Command = "Send Signal"
 	CommandUnknown        Command = "Unknown"
+	CommandViewSockets    Command = "Sockets"
 )
 
 const (
@@ -34,6 +41,11 @@ const (
 	ContextHydrating           Context = "Hydrating"
 	ContextHydrationError      Context = "HydrationError"
 	ContextSendSignal          Context = "SendSignal"
+	ContextConfirmSignal       Context = "ConfirmSignal"
+	ContextProcessGone         Context = "ProcessGone"
+	ContextFilter              Context = "Filter"
+	ContextSocketView          Context = "SocketView"
+	ContextPortLookup          Context = "PortLookup"
 )
 
 const (
@@ -146,6 +158,14 @@ func NewManager() Manager {
 				KeyPresses:  []KeyPress{KeyEnter},
 				Description: "Execute selected",
 			},
+			CommandExport: {
+				KeyPresses:  []KeyPress{KeyE},
+				Description: "Export selected items",
+			},
+			CommandViewSockets: {
+				KeyPresses:  []KeyPress{KeyV},
+				Description: "View sockets of selected items",
+			},
 			CommandSendSignal: {
 				KeyPresses:  []KeyPress{KeyS},
 				Description: "Send signal to item",
@@ -154,10 +174,26 @@ func NewManager() Manager {
 				KeyPresses:  []KeyPress{KeyF},
 				Description: "Filter items",
 			},
+			CommandFindPort: {
+				KeyPresses:  []KeyPress{KeyW},
+				Description: "Find the processes on a port",
+			},
 			CommandOrder: {
 				KeyPresses:  []KeyPress{KeyO},
 				Description: "Order items",
 			},
+			CommandPause: {
+				KeyPresses:  []KeyPress{KeyP},
+				Description: "Pause/resume auto-refresh",
+			},
+			CommandApply: {
+				KeyPresses:  []KeyPress{KeyEnter},
+				Description: "Apply and close input",
+			},
+			CommandReverse: {
+				KeyPresses:  []KeyPress{KeyR},
+				Description: "Reverse order",
+			},
 			CommandBack: {
 				KeyPresses:  []KeyPress{KeyEsc},
 				Description: "Go back",
@@ -166,6 +202,10 @@ func NewManager() Manager {
 				KeyPresses:  []KeyPress{KeyR},
 				Description: "Retry",
 			},
+			CommandConfirm: {
+				KeyPresses:  []KeyPress{KeyY},
+				Description: "Confirm action",
+			},
 			CommandDismiss: {
 				KeyPresses:  []KeyPress{KeyDel},
 				Description: "Dismiss",
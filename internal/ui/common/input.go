@@ -0,0 +1,16 @@
+package common
+
+import tea "charm.land/bubbletea/v2"
+
+// CapturesKey reports whether a key press belongs to a focused text field
+// rather than to the command manager. Everything but navigation and
+// enter/esc is typed into the field
+func CapturesKey(msg tea.KeyPressMsg) bool {
+	switch msg.String() {
+	case "up", "down", "pgup", "pgdown", "enter", "esc", "ctrl+c":
+		return false
+	case "backspace", "delete", "left", "right", "home", "end", "ctrl+u", "ctrl+w":
+		return true
+	}
+	return msg.Text != ""
+}
@@ -1,65 +1,149 @@
 package sendsignal
 
 import (
+	"fmt"
+	"netps/internal/process"
 	"netps/internal/ui/common"
+	"netps/internal/ui/common/command"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"charm.land/bubbles/v2/list"
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 )
 
-type commandListItem string
+type commandListItem struct {
+	signal process.Signal
+}
 
 func (i commandListItem) FilterValue() string { return "" }
 
+func (i commandListItem) String() string {
+	return fmt.Sprintf("%s (%d) · %s", i.signal.Name, int(i.signal.Number), i.signal.Description)
+}
+
 type Model struct {
 	List                list.Model
+	Input               textinput.Model
 	SendSignalHelpItems []string
+	ConfirmHelpItems    []string
 	CommandListItems    []list.Item
 	Modal               string
+
+	lastSignal *process.Signal // remembered for the whole session, survives Initialize()
+	clock      common.Clock
 }
 
-func New() Model {
+func New(clock common.Clock) Model {
 	return Model{
-		List: list.New([]list.Item{}, commandListItemDelegate{}, 25, 6),
+		List:  list.New([]list.Item{}, commandListItemDelegate{}, 25, 6),
+		Input: common.NewTextInput(clock),
+		clock: clock,
 		SendSignalHelpItems: []string{
 			"[↑↓] scroll",
+			"[type] filter by name or number",
 			"[enter] send",
 			"[esc] back",
-			"[q] quit",
 		},
-		CommandListItems: []list.Item{
-			commandListItem("SIGTERM (15) · graceful termination"),
-			commandListItem("SIGKILL (9) · immediate termination"),
-			commandListItem("SIGINT (2) · interrupt"),
-			commandListItem("SIGHUP (1) · reload / restart hint"),
+		ConfirmHelpItems: []string{
+			"[y] confirm",
+			"[esc] cancel",
+			"[q] quit",
 		},
+		CommandListItems: signalListItems(process.Signals),
 	}
 }
 
+// RegisterCommands binds the modal's keys, once per manager since every screen opening the modal shares them
+func RegisterCommands(commandManager *command.Manager) error {
+	err := commandManager.RegisterContextCommand(command.ContextSendSignal, command.KeyUp, command.CommandMove)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextSendSignal, command.KeyDown, command.CommandMove)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextSendSignal, command.KeyEnter, command.CommandExecute)
+	if err != nil {
+		return err
+	}
+	return commandManager.RegisterContextCommand(command.ContextConfirmSignal, command.KeyY, command.CommandConfirm)
+}
+
+func signalListItems(signals []process.Signal) []list.Item {
+	items := []list.Item{}
+	for _, s := range signals {
+		items = append(items, commandListItem{signal: s})
+	}
+	return items
+}
+
 func (m *Model) Initialize() {
 
-	const defaultWidth = 25
-	const listHeight = 6
+	const defaultWidth = 48
+	const listHeight = 12
 
 	l := list.New(m.CommandListItems, commandListItemDelegate{}, defaultWidth, listHeight)
 	l.Title = "Send Signal to Process"
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
-	l.SetShowPagination(false)
+	l.SetShowPagination(true)
 	l.DisableQuitKeybindings()
 	l.SetShowHelp(false)
 
+	input := common.NewTextInput(m.clock)
+	input.Prompt = "signal: "
+	input.Placeholder = "name or number"
+	input.CharLimit = 16
+	input.SetWidth(defaultWidth - len(input.Prompt))
+
 	m.List = l
+	m.Input = input
 	m.updateStyles()
+	m.Open()
+}
+
+// Open resets the filter and preselects the last signal sent in this session,
+// falling back to SIGTERM
+func (m *Model) Open() {
+	m.Input.Reset()
+	m.Input.Focus()
+	m.applyFilter()
+
+	preselect := syscall.SIGTERM
+	if m.lastSignal != nil {
+		preselect = m.lastSignal.Number
+	}
+	m.selectSignal(preselect)
+	m.Modal = m.render()
+}
+
+// Remember stores the signal that was just sent so the next Open() preselects it
+func (m *Model) Remember(sig process.Signal) {
+	m.lastSignal = &sig
 }
 
 func (m Model) Init() tea.Cmd { return nil }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
-	m.List, cmd = m.List.Update(msg)
-	m.Modal = common.CommandModal(m.List.View())
+
+	keyMsg, isKey := msg.(tea.KeyPressMsg)
+	if isKey && common.CapturesKey(keyMsg) {
+		before := m.Input.Value()
+		m.Input, cmd = m.Input.Update(msg)
+		if m.Input.Value() != before {
+			m.applyFilter()
+		}
+	} else {
+		m.List, cmd = m.List.Update(msg)
+	}
+
+	m.Modal = m.render()
 	return m, cmd
 }
 
@@ -69,6 +153,84 @@ func (m Model) View() tea.View {
 	return v
 }
 
+// SelectedSignal returns the signal currently highlighted in the list
+func (m Model) SelectedSignal() (process.Signal, bool) {
+	item, ok := m.List.SelectedItem().(commandListItem)
+	if !ok {
+		return process.Signal{}, false
+	}
+	return item.signal, true
+}
+
+// ConfirmModal renders the confirmation step shown before a destructive signal is sent
+func ConfirmModal(sig process.Signal, pid int, name string) string {
+	text := fmt.Sprintf("Send %s (%d) to %s (%d)?\n%s\n\n[y] confirm · [esc] cancel", sig.Name, int(sig.Number), name, pid, sig.Description)
+	return common.CommandModal(text)
+}
+
+// ConfirmBulkModal is ConfirmModal for several processes at once, names are listed up to a few
+func ConfirmBulkModal(sig process.Signal, names []string) string {
+	const maxListed = 5
+	listed := strings.Join(names[:min(len(names), maxListed)], ", ")
+	if len(names) > maxListed {
+		listed += fmt.Sprintf(" +%d more", len(names)-maxListed)
+	}
+	text := fmt.Sprintf("Send %s (%d) to %d processes?\n%s\n%s\n\n[y] confirm · [esc] cancel", sig.Name, int(sig.Number), len(names), listed, sig.Description)
+	return common.CommandModal(text)
+}
+
+// Narrows the list down to the signals matching the typed text.
+// An exact match (e.g. "9" or "kill") is selected so enter sends it directly
+func (m *Model) applyFilter() {
+	query := strings.TrimSpace(m.Input.Value())
+	if query == "" {
+		m.List.SetItems(m.CommandListItems)
+		return
+	}
+
+	filtered := []list.Item{}
+	for _, item := range m.CommandListItems {
+		if matchesSignal(item.(commandListItem).signal, query) {
+			filtered = append(filtered, item)
+		}
+	}
+	m.List.SetItems(filtered)
+	m.List.ResetSelected()
+
+	if exact, err := process.ParseSignal(query); err == nil {
+		m.selectSignal(exact.Number)
+	}
+}
+
+func (m *Model) selectSignal(number syscall.Signal) {
+	for i, item := range m.List.Items() {
+		if item.(commandListItem).signal.Number == number {
+			m.List.Select(i)
+			return
+		}
+	}
+}
+
+func matchesSignal(sig process.Signal, query string) bool {
+	q := strings.ToUpper(query)
+	name := strings.TrimPrefix(sig.Name, "SIG")
+	if strings.HasPrefix(name, strings.TrimPrefix(q, "SIG")) {
+		return true
+	}
+	if strings.HasPrefix(strconv.Itoa(int(sig.Number)), q) {
+		return true
+	}
+	return strings.Contains(strings.ToUpper(sig.Description), q)
+}
+
+func (m Model) render() string {
+	body := m.List.View()
+	if len(m.List.Items()) == 0 {
+		body = lipgloss.JoinVertical(lipgloss.Left, m.List.Styles.Title.Render(m.List.Title), "", "  no matching signal")
+	}
+	return common.CommandModal(lipgloss.JoinVertical(lipgloss.Left, m.Input.View(), "", body))
+}
+
 func (m *Model) updateStyles() {
 	var s commandListStyles
 	s.title = lipgloss.NewStyle().Foreground(lipgloss.Color("255"))               // ColorWhite
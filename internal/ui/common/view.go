@@ -21,8 +21,8 @@ const (
 const ActionBarItemSeparator = " · "
 
 type Notification struct {
-	ColorState ColorState
-	Info       string
+	ColorMode ColorMode
+	Info      string
 }
 
 func ErrorPanel(theme Theme, width int, errorsStrings []string) string {
@@ -6,3 +6,12 @@ type GoToProcessDetail struct {
 }
 
 type GoBack struct{}
+
+type ProcessRef struct {
+	PID  int
+	Name string
+}
+
+type GoToSocketView struct {
+	Processes []ProcessRef
+}
@@ -85,7 +85,7 @@ func HydrateSockets(ctx context.Context, pid int, socketService *socket.Service)
 			return socketsHydratedMsg{Err: ctx.Err()} // Propagate error
 		}
 
-		socketStates := []socket.SocketState{socket.StateListen, socket.StateEstablished, socket.StateClose}
+		socketStates := []socket.SocketState{socket.StateListen, socket.StateEstablished, socket.StateClose, socket.StateUnconnected}
 		sockets, err := socketService.GetSocketsByStates(ctx, pid, socketStates)
 
 		msg := socketsHydratedMsg{}
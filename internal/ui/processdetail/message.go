@@ -1,6 +1,7 @@
 package processdetail
 
 import (
+	"netps/internal/process"
 	"netps/internal/socket"
 	"time"
 )
@@ -42,6 +43,8 @@ type ResourceHydrationData struct {
 	STime       time.Duration
 	state       HydrationState
 	err         error
+	refreshErr  error // last refresh failed, the values above are from the sample before
+	refreshing  bool
 }
 
 type UserHydrationData struct {
@@ -50,12 +53,15 @@ type UserHydrationData struct {
 	UserPrivileged string
 	state          HydrationState
 	err            error
+	refreshErr     error
 }
 
 type SocketsHydrationData struct {
-	Sockets []socket.Socket
-	state   HydrationState
-	err     error
+	Sockets    []socket.Socket
+	state      HydrationState
+	err        error
+	refreshErr error
+	refreshing bool
 }
 
 type staticIdHydratedMsg struct {
@@ -99,16 +105,44 @@ type sendSignalMsg struct{}
 
 type closeSendSignalModalMsg struct{}
 
+type confirmSignalMsg struct {
+	Signal process.Signal
+}
+
+type cancelConfirmSignalMsg struct{}
+
+type deliverSignalMsg struct {
+	Signal process.Signal
+}
+
+type signalDeliveredMsg struct {
+	Signal process.Signal
+	PID    int
+	Err    error
+}
+
 type dismissnotificationMsg struct{}
 
+type dismissSignalNotificationMsg struct{}
+
 type retryMsg struct{}
 
+type refreshTickMsg struct {
+	seq int
+}
+
 func (initMsg) isSideEffect()             {}
 func (staticIdHydratedMsg) isSideEffect() {}
 func (resourceHydratedMsg) isSideEffect() {}
 func (userHydratedMsg) isSideEffect()     {}
 func (socketsHydratedMsg) isSideEffect()  {}
-
-func (sendSignalMsg) isUIState()           {}
-func (closeSendSignalModalMsg) isUIState() {}
-func (dismissnotificationMsg) isUIState()  {}
+func (signalDeliveredMsg) isSideEffect()  {}
+func (refreshTickMsg) isSideEffect()      {}
+
+func (sendSignalMsg) isUIState()                {}
+func (closeSendSignalModalMsg) isUIState()      {}
+func (dismissnotificationMsg) isUIState()       {}
+func (confirmSignalMsg) isUIState()             {}
+func (cancelConfirmSignalMsg) isUIState()       {}
+func (deliverSignalMsg) isUIState()             {}
+func (dismissSignalNotificationMsg) isUIState() {}
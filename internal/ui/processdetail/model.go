@@ -7,7 +7,14 @@ package processdetail
  - Resource: all info related to resources such as CPU and memory
  - User: Ownership-related info
  - Sockets: sockets info, shows address, port, protocol, currently
- 			scoped to only show Listen, Established, and Closed
+ 			scoped to only show Listen, Established, Closed and Unconnected (packet, unix dgram)
+
+ Resources and sockets are re-hydrated on every refresh tick once they succeeded,
+ static ids and user never change for a PID. Values that changed since the previous
+ sample are marked until the next one. A failed refresh keeps the previous data and
+ reports the error in the status bar, the error panel is for sections that never loaded.
+ A process found exited (or its PID reused)
+ switches the screen to the process gone state for good, no stale data is shown.
 
 	Tech Debts:
 		High:
@@ -44,17 +51,17 @@ package processdetail
 
 import (
 	"context"
+	"errors"
 	"log"
 	"netps/internal/process"
-	"netps/internal/procfs"
 	"netps/internal/socket"
-	"netps/internal/sysconf"
 	"netps/internal/ui/common"
 	"netps/internal/ui/common/command"
 	"netps/internal/ui/common/sendsignal"
 	"netps/internal/ui/message"
 
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
@@ -81,11 +88,13 @@ const (
 	StateHydrationsFinishedErrorDismissed
 	StateHydrationsFinishedAllOK
 	StateRetryHydrations
+	StateProcessGone
 )
 
 const (
 	ModeIdle Mode = iota
 	ModeSendSignal
+	ModeConfirmSignal
 )
 
 type Model struct {
@@ -97,6 +106,12 @@ type Model struct {
 	userHydration     UserHydrationData
 	socketsHydration  SocketsHydrationData
 
+	refreshInterval time.Duration // zero disables auto-refresh
+	refreshSeq      int
+	resourceChanges resourceChanges
+	socketChanges   socketChanges
+	processGone     bool
+
 	windowWidth   int
 	windowHeight  int
 	viewportModel viewport.Model
@@ -104,8 +119,11 @@ type Model struct {
 	operationMode Mode
 
 	sendSignalModalModel sendsignal.Model
+	pendingSignal        process.Signal
+	signalNotification   *common.Notification
 
 	appTheme       common.Theme
+	clock          common.Clock
 	ctx            context.Context
 	cancel         context.CancelFunc
 	processService *process.Service
@@ -117,22 +135,9 @@ type Model struct {
 
 type styleFunc func(string) string
 
-func New(theme common.Theme, commandManager *command.Manager) (Model, error) {
-	sendSignal := sendsignal.New()
+func New(theme common.Theme, clock common.Clock, commandManager *command.Manager, processService *process.Service, socketService *socket.Service) (Model, error) {
+	sendSignal := sendsignal.New(clock)
 	ctx, cancel := context.WithCancel(context.Background())
-	procfsClient := procfs.NewClient()
-	sysconfClient := sysconf.NewClient()
-	cfg := process.Config{
-		Process:   procfsClient,
-		Detail:    procfsClient,
-		Clocktick: sysconfClient,
-		PageSize:  sysconfClient,
-		UpTime:    procfsClient,
-		Resource:  procfsClient,
-		User:      procfsClient,
-	}
-	processService := process.NewProcessService(cfg)
-	socketService := socket.NewService(procfsClient)
 
 	err := commandManager.SetContext(command.ContextProcessListScreen)
 	if err != nil {
@@ -149,6 +154,7 @@ func New(theme common.Theme, commandManager *command.Manager) (Model, error) {
 	return Model{
 		sendSignalModalModel: sendSignal,
 		appTheme:             theme,
+		clock:                clock,
 		staticIdHydration:    StaticIdHydrationData{},
 		resourceHydration:    ResourceHydrationData{},
 		userHydration:        UserHydrationData{},
@@ -161,6 +167,11 @@ func New(theme common.Theme, commandManager *command.Manager) (Model, error) {
 	}, err
 }
 
+func (m Model) WithRefreshInterval(interval time.Duration) Model {
+	m.refreshInterval = interval
+	return m
+}
+
 func (m Model) Init(pid int, name string, width, height int) tea.Cmd {
 	return tea.Sequence(
 		Initialize(pid, name, width, height),
@@ -201,8 +212,18 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.windowHeight = msg.height
 		m.sendSignalModalModel.Initialize()
 		m.setAllHydrationState(StateHydrating)
+		if m.refreshInterval > 0 {
+			m.refreshSeq++ // orphans the tick chain of a previous visit
+			cmds = append(cmds, ScheduleRefresh(m.clock, m.refreshInterval, m.refreshSeq))
+		}
+	case refreshTickMsg:
+		if msg.seq != m.refreshSeq || m.processGone {
+			return m, nil
+		}
+		cmds = append(cmds, ScheduleRefresh(m.clock, m.refreshInterval, m.refreshSeq))
+		cmds = append(cmds, m.collectRefreshCommands()...)
 	case retryMsg:
-		if m.operationMode == ModeSendSignal {
+		if m.operationMode != ModeIdle {
 			m.operationMode = ModeIdle
 		}
 		m.resetContext()
@@ -230,7 +251,30 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			dataChanged = true
 		}
 	case resourceHydratedMsg:
-		if msg.Err == nil && m.resourceStatusWouldChange(StateSuccess, msg.Err) {
+		m.resourceHydration.refreshing = false
+		if m.processGone {
+			break
+		}
+		if errors.Is(msg.Err, process.ErrProcessGone) || m.pidReused(msg) {
+			m.processGone = true
+			dataChanged = true
+			break
+		}
+
+		if msg.Err != nil && m.resourceHydration.state == StateSuccess {
+			// a failed refresh keeps the last good sample on screen, the status bar tells it is stale
+			m.resourceHydration.refreshErr = msg.Err
+		} else if msg.Err == nil && m.resourceHydration.state == StateSuccess {
+			// refreshed sample, only the values move
+			m.resourceHydration.refreshErr = nil
+			m.resourceChanges = diffResource(m.resourceHydration, msg)
+			m.resourceHydration.RSSByte = msg.RSSByte
+			m.resourceHydration.ElapsedTime = msg.ElapsedTime
+			m.resourceHydration.VSZByte = msg.VSZByte
+			m.resourceHydration.UTime = msg.UTime
+			m.resourceHydration.STime = msg.STime
+			dataChanged = true
+		} else if msg.Err == nil && m.resourceStatusWouldChange(StateSuccess, msg.Err) {
 			m.resourceHydration.state = StateSuccess
 			m.resourceHydration.err = nil
 			m.resourceHydration.RSSByte = msg.RSSByte
@@ -246,9 +290,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			dataChanged = true
 		}
 	case userHydratedMsg:
-		if msg.Err == nil && m.userStatusWouldChange(StateSuccess, msg.Err) {
+		if msg.Err != nil && m.userHydration.state == StateSuccess {
+			m.userHydration.refreshErr = msg.Err
+		} else if msg.Err == nil && m.userStatusWouldChange(StateSuccess, msg.Err) {
 			m.userHydration.state = StateSuccess
 			m.userHydration.err = nil
+			m.userHydration.refreshErr = nil
 			m.userHydration.UserUID = msg.UserUID
 			m.userHydration.UserName = msg.UserName
 			m.userHydration.UserPrivileged = msg.UserPrivileged
@@ -259,7 +306,19 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			dataChanged = true
 		}
 	case socketsHydratedMsg:
-		if msg.Err == nil && m.socketsStatusWouldChange(StateSuccess, msg.Err) {
+		m.socketsHydration.refreshing = false
+		if m.processGone {
+			break
+		}
+
+		if msg.Err != nil && m.socketsHydration.state == StateSuccess {
+			m.socketsHydration.refreshErr = msg.Err
+		} else if msg.Err == nil && m.socketsHydration.state == StateSuccess {
+			m.socketsHydration.refreshErr = nil
+			m.socketChanges = diffSockets(m.socketsHydration.Sockets, msg.Sockets)
+			m.socketsHydration.Sockets = msg.Sockets
+			dataChanged = true
+		} else if msg.Err == nil && m.socketsStatusWouldChange(StateSuccess, msg.Err) {
 			m.socketsHydration.state = StateSuccess
 			m.socketsHydration.err = nil
 			m.socketsHydration.Sockets = msg.Sockets
@@ -271,10 +330,31 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 	case sendSignalMsg:
 		m.operationMode = ModeSendSignal
+		m.sendSignalModalModel.Open()
 		viewportContentColorChanged = true // opening send signal modal changed the viewport's content color to dim which required to rerender the viewport
 	case closeSendSignalModalMsg:
 		m.operationMode = ModeIdle
 		viewportContentColorChanged = true // closing send signal modal changed the viewport's content color to normal which required to rerender the viewport
+	case confirmSignalMsg:
+		m.operationMode = ModeConfirmSignal
+		m.pendingSignal = msg.Signal
+	case cancelConfirmSignalMsg:
+		m.operationMode = ModeSendSignal
+		m.pendingSignal = process.Signal{}
+	case deliverSignalMsg:
+		m.operationMode = ModeIdle
+		m.pendingSignal = process.Signal{}
+		m.sendSignalModalModel.Remember(msg.Signal)
+		viewportContentColorChanged = true
+		cmds = append(cmds, DeliverSignal(m.ctx, m.PID, msg.Signal, m.processService))
+	case signalDeliveredMsg:
+		// A late result for a previously shown process is not relevant anymore
+		if msg.PID == m.PID {
+			notification := signalNotification(msg.Signal, msg.PID, m.ProcessName, msg.Err)
+			m.signalNotification = &notification
+		}
+	case dismissSignalNotificationMsg:
+		m.signalNotification = nil
 	case dismissnotificationMsg:
 		// Dismissing errors hides the panel but does not change data completeness.
 		// dismissal is not errors resolution
@@ -282,6 +362,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		// TO-DO: Implement retry on-demand when the data is partial, even after dismiss
 		m.resetAllErrors()
 	case tea.KeyMsg:
+		// The signal modal has a free-text field, typed characters must not trigger commands
+		if press, ok := msg.(tea.KeyPressMsg); ok && m.operationMode == ModeSendSignal && common.CapturesKey(press) {
+			m.sendSignalModalModel, cmd = m.sendSignalModalModel.Update(msg)
+			return m, cmd
+		}
+
 		c := m.commandManager.GetCommand(command.ToKeyPress(msg.String()))
 
 		switch c {
@@ -295,6 +381,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m.handleErrorRetryKey()
 		case command.CommandDismiss:
 			return m.handleNotificationDismissKey()
+		case command.CommandExecute:
+			return m.handleExecute()
+		case command.CommandConfirm:
+			return m.handleConfirm()
 		}
 	}
 
@@ -311,10 +401,14 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		log.Fatalf("Process Detail Screen error at update: %v", err)
 	}
 
-	if m.operationMode == ModeSendSignal {
+	switch m.operationMode {
+	case ModeSendSignal:
 		m.sendSignalModalModel, cmd = m.sendSignalModalModel.Update(msg)
-		return m, cmd
-	} else {
+		cmds = append(cmds, cmd)
+		return m, tea.Batch(cmds...)
+	case ModeConfirmSignal:
+		return m, tea.Batch(cmds...)
+	default:
 		m.viewportModel, cmd = m.viewportModel.Update(msg)
 		cmds = append(cmds, cmd)
 		return m, tea.Batch(cmds...)
@@ -336,15 +430,11 @@ func (m Model) View() tea.View {
 		layers := []*lipgloss.Layer{}
 		helpItems := m.commandManager.GenerateContextHelp()
 
-		if m.operationMode == ModeSendSignal {
-			signalList := m.sendSignalModalModel
-			signalListWidth := lipgloss.Width(signalList.Modal)
-			signalListHeight := lipgloss.Height(signalList.Modal)
-			modalLayer := lipgloss.NewLayer(signalList.View().Content).
-				X((m.windowWidth / 2) - (signalListWidth / 2)).
-				Y((m.windowHeight / 2) - (signalListHeight / 2)).
-				Z(1)
-			layers = append(layers, modalLayer)
+		switch m.operationMode {
+		case ModeSendSignal:
+			layers = append(layers, m.modalLayer(m.sendSignalModalModel.Modal))
+		case ModeConfirmSignal:
+			layers = append(layers, m.modalLayer(sendsignal.ConfirmModal(m.pendingSignal, m.PID, m.ProcessName)))
 		}
 
 		ui := renderBaseLayer(
@@ -356,6 +446,8 @@ func (m Model) View() tea.View {
 			scrollingInfo(m.getScrollingPercent(), m.getVisibleContentPercent()),
 			helpItems,
 			m.getErrorsAsString(),
+			m.getRefreshErrorAsString(),
+			m.signalNotification,
 			screenState,
 			0,
 		)
@@ -367,6 +459,16 @@ func (m Model) View() tea.View {
 	return v
 }
 
+// Centers a modal on top of the base layer
+func (m Model) modalLayer(modal string) *lipgloss.Layer {
+	modalWidth := lipgloss.Width(modal)
+	modalHeight := lipgloss.Height(modal)
+	return lipgloss.NewLayer(modal).
+		X((m.windowWidth / 2) - (modalWidth / 2)).
+		Y((m.windowHeight / 2) - (modalHeight / 2)).
+		Z(1)
+}
+
 func renderBaseLayer(
 	theme common.Theme,
 	content string,
@@ -376,16 +478,27 @@ func renderBaseLayer(
 	statusBarInfo string,
 	helpItems []string,
 	errors []string,
+	refreshError string,
+	notification *common.Notification,
 	screenState ScreenState,
 	zIndex int,
 ) *lipgloss.Layer {
 	components := []string{}
 	components = append(components, content)
 
+	if notification != nil {
+		components = append(components, common.NotificationBar(theme, notification.ColorMode, width, notification.Info))
+	}
+
 	actionBar := common.ActionBar(width, helpItems)
 
 	var screenStateInfoLabel string
 	var statusBar string
+	dataOK, dataOKColor := "Data OK", common.ColorModeSuccess
+	if refreshError != "" {
+		screenStateInfoLabel = " · " + refreshError
+		dataOK, dataOKColor = "Data Stale", common.ColorModeWarning
+	}
 
 	switch screenState {
 	case StateHydrationsInProgress, StateOneHydrationFinished:
@@ -400,7 +513,9 @@ func renderBaseLayer(
 	case StateHydrationsFinishedErrorDismissed:
 		statusBar = common.StatusBar(theme, width, modeName, colorMode, lipgloss.JoinHorizontal(lipgloss.Top, statusBarInfo, screenStateInfoLabel), "Data Partial", common.ColorModeWarning)
 	case StateHydrationsFinishedAllOK:
-		statusBar = common.StatusBar(theme, width, modeName, colorMode, lipgloss.JoinHorizontal(lipgloss.Top, statusBarInfo, screenStateInfoLabel), "Data OK", common.ColorModeSuccess)
+		statusBar = common.StatusBar(theme, width, modeName, colorMode, lipgloss.JoinHorizontal(lipgloss.Top, statusBarInfo, screenStateInfoLabel), dataOK, dataOKColor)
+	case StateProcessGone:
+		statusBar = common.StatusBar(theme, width, modeName, colorMode, statusBarInfo, "Process Gone", common.ColorModeWarning)
 	default:
 		screenStateInfoLabel = ""
 	}
@@ -427,12 +542,18 @@ func (m *Model) adjustViewportSize() {
 		actionBar = common.ActionBar(m.windowWidth, m.commandManager.GenerateContextHelp())
 	case ModeSendSignal:
 		actionBar = common.ActionBar(m.windowWidth, m.sendSignalModalModel.SendSignalHelpItems)
+	case ModeConfirmSignal:
+		actionBar = common.ActionBar(m.windowWidth, m.sendSignalModalModel.ConfirmHelpItems)
 	default:
 		actionBar = ""
 	}
 
 	statusBarHeight := lipgloss.Height(statusBar)
 	actionBarHeight := lipgloss.Height(actionBar)
+	notificationHeight := 0
+	if m.signalNotification != nil {
+		notificationHeight = lipgloss.Height(common.NotificationBar(m.appTheme, m.signalNotification.ColorMode, m.windowWidth, m.signalNotification.Info))
+	}
 
 	// Viewport Height Calculation
 	//
@@ -451,13 +572,13 @@ func (m *Model) adjustViewportSize() {
 		m.viewportModel.SetWidth(m.windowWidth)
 		errorsPanelHeight := lipgloss.Height(errorPanel)
 		if len(m.getErrorsAsString()) > 0 {
-			m.viewportModel.SetHeight(m.windowHeight - errorsPanelHeight - statusBarHeight - actionBarHeight)
+			m.viewportModel.SetHeight(m.windowHeight - errorsPanelHeight - notificationHeight - statusBarHeight - actionBarHeight)
 		} else {
-			m.viewportModel.SetHeight(m.windowHeight - statusBarHeight - actionBarHeight)
+			m.viewportModel.SetHeight(m.windowHeight - notificationHeight - statusBarHeight - actionBarHeight)
 		}
 	default:
 		m.viewportModel.SetWidth(m.windowWidth)
-		m.viewportModel.SetHeight(m.windowHeight - statusBarHeight - actionBarHeight)
+		m.viewportModel.SetHeight(m.windowHeight - notificationHeight - statusBarHeight - actionBarHeight)
 	}
 
 	m.viewportModel.SetYOffset(savedY) // Restore scroll position
@@ -476,16 +597,22 @@ func (m *Model) getScrollingPercent() float64 {
 }
 
 func (m *Model) modeName() string {
-	if m.operationMode == ModeSendSignal {
+	switch m.operationMode {
+	case ModeSendSignal:
 		return "Send Signal"
+	case ModeConfirmSignal:
+		return "Confirm Signal"
 	}
 	return "Process Detail"
 }
 
 func (m *Model) modeColor() common.ColorMode {
-	if m.operationMode == ModeSendSignal {
+	switch m.operationMode {
+	case ModeSendSignal:
 		return common.ColorModeSpecial
-	} else {
+	case ModeConfirmSignal:
+		return common.ColorModeWarning
+	default:
 		return common.ColorModeNeutral
 	}
 }
@@ -499,6 +626,11 @@ func (m *Model) resetAllData() {
 	m.resourceHydration = ResourceHydrationData{}
 	m.userHydration = UserHydrationData{}
 	m.socketsHydration = SocketsHydrationData{}
+	m.resourceChanges = nil
+	m.socketChanges = socketChanges{}
+	m.processGone = false
+	m.pendingSignal = process.Signal{}
+	m.signalNotification = nil
 	m.viewportModel.SetContent("")
 }
 
@@ -517,6 +649,10 @@ func (m *Model) resetAllHydrationStatus() {
 }
 
 func (m *Model) renderContent() string {
+	if m.processGone {
+		return strings.TrimSpace(processGoneSection(m.appTheme, m.windowWidth, m.ProcessName, m.PID))
+	}
+
 	ui := processDetailSection(
 		m.operationMode == ModeIdle,
 		m.appTheme,
@@ -537,6 +673,8 @@ func (m *Model) renderContent() string {
 		m.resourceHydration.ElapsedTime,
 		m.resourceHydration.UTime,
 		m.resourceHydration.STime,
+		m.resourceChanges,
+		m.socketChanges,
 	)
 	trimmed := strings.TrimSpace(ui)
 	return trimmed
@@ -544,7 +682,11 @@ func (m *Model) renderContent() string {
 
 func (m Model) handleEsc() (Model, tea.Cmd) {
 	screenState := m.computeScreenState()
-	if m.operationMode == ModeSendSignal {
+	if m.operationMode == ModeConfirmSignal {
+		return m, func() tea.Msg {
+			return cancelConfirmSignalMsg{}
+		}
+	} else if m.operationMode == ModeSendSignal {
 		return m, func() tea.Msg {
 			return closeSendSignalModalMsg{}
 		}
@@ -562,7 +704,7 @@ func (m Model) handleEsc() (Model, tea.Cmd) {
 
 func (m Model) handleQ() (Model, tea.Cmd) {
 	screenState := m.computeScreenState()
-	if m.operationMode == ModeSendSignal {
+	if m.operationMode == ModeSendSignal || m.operationMode == ModeConfirmSignal {
 		return m, func() tea.Msg {
 			return closeSendSignalModalMsg{}
 		}
@@ -578,7 +720,8 @@ func (m Model) handleS() (Model, tea.Cmd) {
 
 	// User can send signal as long as the PID is retrived
 	// which is already have passed by process list screen (not from hydrating)
-	if m.operationMode == ModeIdle && m.computeScreenState() != StateInit {
+	screenState := m.computeScreenState()
+	if m.operationMode == ModeIdle && screenState != StateInit && screenState != StateProcessGone {
 		return m, func() tea.Msg {
 			return sendSignalMsg{}
 		}
@@ -589,12 +732,57 @@ func (m Model) handleS() (Model, tea.Cmd) {
 	}
 }
 
+// Executing a signal from the modal only asks for confirmation when the signal
+// would terminate the process. Anything else is delivered right away
+func (m Model) handleExecute() (Model, tea.Cmd) {
+	if m.operationMode != ModeSendSignal {
+		return m, func() tea.Msg {
+			return nil
+		}
+	}
+
+	sig, ok := m.sendSignalModalModel.SelectedSignal()
+	if !ok {
+		return m, func() tea.Msg {
+			return nil
+		}
+	}
+
+	if sig.Destructive {
+		return m, func() tea.Msg {
+			return confirmSignalMsg{Signal: sig}
+		}
+	}
+	return m, func() tea.Msg {
+		return deliverSignalMsg{Signal: sig}
+	}
+}
+
+func (m Model) handleConfirm() (Model, tea.Cmd) {
+	if m.operationMode != ModeConfirmSignal {
+		return m, func() tea.Msg {
+			return nil
+		}
+	}
+
+	sig := m.pendingSignal
+	return m, func() tea.Msg {
+		return deliverSignalMsg{Signal: sig}
+	}
+}
+
 func (m Model) handleNotificationDismissKey() (Model, tea.Cmd) {
+	if m.operationMode == ModeIdle && m.signalNotification != nil {
+		return m, func() tea.Msg {
+			return dismissSignalNotificationMsg{}
+		}
+	}
+
 	switch m.computeScreenState() {
 	case StateHydrationsFinishedErrorsExist:
-		if m.operationMode == ModeSendSignal {
+		if m.operationMode != ModeIdle {
 			return m, func() tea.Msg {
-				return nil // do nothing for now; next will implement proper signal sending logic
+				return nil // errors can't be dismissed while the signal modal is open
 			}
 		} else {
 			return m, func() tea.Msg {
@@ -649,6 +837,20 @@ func (m *Model) getErrorsAsString() []string {
 	return errorStrings
 }
 
+// Refresh failures of sections that already have data, shown in the status bar instead of the error panel
+func (m *Model) getRefreshErrorAsString() string {
+	errs := []string{}
+	for _, err := range []error{m.resourceHydration.refreshErr, m.userHydration.refreshErr, m.socketsHydration.refreshErr} {
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) == 0 {
+		return ""
+	}
+	return "refresh failed: " + strings.Join(errs, "; ")
+}
+
 func (m *Model) hydrationErrorsExist() bool {
 	return m.staticIdHydration.err != nil ||
 		m.resourceHydration.err != nil ||
@@ -696,6 +898,9 @@ func (m *Model) allHydrationOK() bool {
 // Multiple subsystems query computeScreenState() in other place is intentional for now
 // TO-DO: State caching
 func (m *Model) computeScreenState() ScreenState {
+	if m.processGone {
+		return StateProcessGone
+	}
 	if m.allHydrationFinished() {
 		if m.allHydrationOK() {
 			return StateHydrationsFinishedAllOK
@@ -782,6 +987,31 @@ func (m *Model) collectRetryCommands() []tea.Cmd {
 	return commands
 }
 
+// Refreshes only the sections that hydrated successfully, a failed section
+// waits for an explicit retry so the error panel does not flap
+func (m *Model) collectRefreshCommands() []tea.Cmd {
+	commands := []tea.Cmd{}
+
+	if m.resourceHydration.state == StateSuccess && !m.resourceHydration.refreshing {
+		m.resourceHydration.refreshing = true
+		commands = append(commands, HydrateResource(m.ctx, m.PID, m.processService))
+	}
+
+	if m.socketsHydration.state == StateSuccess && !m.socketsHydration.refreshing {
+		m.socketsHydration.refreshing = true
+		commands = append(commands, HydrateSockets(m.ctx, m.PID, m.socketService))
+	}
+
+	return commands
+}
+
+// A different start time means the PID now belongs to another process
+func (m *Model) pidReused(sample resourceHydratedMsg) bool {
+	return sample.Err == nil &&
+		m.resourceHydration.state == StateSuccess &&
+		m.resourceHydration.StartTime != sample.StartTime
+}
+
 // determines if retry is needed
 func (m *Model) shouldRetry(err error) bool {
 	return err != nil
@@ -812,6 +1042,10 @@ func registerContextualCommands(commandManager *command.Manager) error {
 	if err != nil {
 		return err
 	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessDetailScreen, command.KeyDel, command.CommandDismiss)
+	if err != nil {
+		return err
+	}
 
 	err = commandManager.RegisterContextCommand(command.ContextHydrationError, command.KeyR, command.CommandRetry)
 	if err != nil {
@@ -834,15 +1068,11 @@ func registerContextualCommands(commandManager *command.Manager) error {
 		return err
 	}
 
-	err = commandManager.RegisterContextCommand(command.ContextSendSignal, command.KeyUp, command.CommandMove)
-	if err != nil {
-		return err
-	}
-	err = commandManager.RegisterContextCommand(command.ContextSendSignal, command.KeyDown, command.CommandMove)
+	err = commandManager.RegisterContextCommand(command.ContextProcessGone, command.KeyUp, command.CommandScroll)
 	if err != nil {
 		return err
 	}
-	err = commandManager.RegisterContextCommand(command.ContextSendSignal, command.KeyEnter, command.CommandExecute)
+	err = commandManager.RegisterContextCommand(command.ContextProcessGone, command.KeyDown, command.CommandScroll)
 	if err != nil {
 		return err
 	}
@@ -851,10 +1081,14 @@ func registerContextualCommands(commandManager *command.Manager) error {
 
 func (m *Model) setCurrentCommandContext() error {
 	var err error
-	if m.operationMode == ModeSendSignal {
+	if m.operationMode == ModeConfirmSignal {
+		err = m.commandManager.SetContext(command.ContextConfirmSignal)
+	} else if m.operationMode == ModeSendSignal {
 		err = m.commandManager.SetContext(command.ContextSendSignal)
 	} else {
 		switch m.computeScreenState() {
+		case StateProcessGone:
+			err = m.commandManager.SetContext(command.ContextProcessGone)
 		case StateHydrationsFinishedAllOK, StateHydrationsFinishedErrorDismissed:
 			err = m.commandManager.SetContext(command.ContextProcessDetailScreen)
 		case StateHydrationsFinishedErrorsExist:
@@ -0,0 +1,177 @@
+package processdetail
+
+import (
+	"errors"
+	"netps/internal/ui/uitest"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const nginxPID = 812
+
+// screen gives the detail the tea.Model shape ui.Root gives it, opened on nginx
+type screen struct {
+	Model
+	width, height int
+}
+
+func (s screen) Init() tea.Cmd {
+	return s.Model.Init(nginxPID, "nginx", s.width, s.height)
+}
+
+func (s screen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	s.Model, cmd = s.Model.Update(msg)
+	return s, cmd
+}
+
+func start(t *testing.T, host *uitest.Host) *uitest.Driver {
+	t.Helper()
+	return startRefreshing(t, host, 0)
+}
+
+func startRefreshing(t *testing.T, host *uitest.Host, interval time.Duration) *uitest.Driver {
+	t.Helper()
+	processService, socketService := host.Services()
+	m, err := New(uitest.Theme, uitest.Clock, uitest.Commands(t), processService, socketService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = m.WithRefreshInterval(interval)
+	return uitest.Start(t, screen{Model: m, width: 100, height: 30}, 100, 30)
+}
+
+func state(d *uitest.Driver) ScreenState {
+	m := d.Model().(screen).Model
+	return m.computeScreenState()
+}
+
+func TestHydrated(t *testing.T) {
+	d := start(t, uitest.NewHost(uitest.Machine()))
+	if got := state(d); got != StateHydrationsFinishedAllOK {
+		t.Fatalf("state %d, want all hydrations OK", got)
+	}
+	uitest.Golden(t, "hydrated", d.Frame())
+}
+
+func TestRetryHydrationError(t *testing.T) {
+	hydrated := start(t, uitest.NewHost(uitest.Machine())).Frame()
+
+	host := uitest.NewHost(uitest.Machine())
+	host.Fail(uitest.SectionSockets, errors.New("open /proc/812/fd: permission denied"))
+	d := start(t, host)
+	if got := state(d); got != StateHydrationsFinishedErrorsExist {
+		t.Fatalf("state %d, want finished with errors", got)
+	}
+	uitest.Golden(t, "hydration_error", d.Frame())
+
+	// still failing: the error stays up
+	d.Press("r")
+	if got := state(d); got != StateHydrationsFinishedErrorsExist {
+		t.Fatalf("state %d after a failed retry, want finished with errors", got)
+	}
+
+	host.Recover(uitest.SectionSockets)
+	d.Press("r")
+	if got := d.Frame(); got != hydrated {
+		t.Errorf("frame after a successful retry:\n%s\nwant:\n%s", got, hydrated)
+	}
+}
+
+// A section that already hydrated keeps its last data when a refresh fails, the error goes to the status bar
+func TestRefreshFailureKeepsData(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	d := startRefreshing(t, host, time.Hour)
+	hydrated := d.Frame()
+
+	host.Fail(uitest.SectionSockets, errors.New("open /proc/812/fd: permission denied"))
+	d.Send(refreshTickMsg{seq: 1})
+	if got := state(d); got != StateHydrationsFinishedAllOK {
+		t.Fatalf("state %d after a failed refresh, want all hydrations OK", got)
+	}
+	uitest.Golden(t, "refresh_failed", d.Frame())
+
+	host.Recover(uitest.SectionSockets)
+	d.Send(refreshTickMsg{seq: 1})
+	if got := d.Frame(); got != hydrated {
+		t.Errorf("frame after a successful refresh:\n%s\nwant:\n%s", got, hydrated)
+	}
+}
+
+func TestDismissHydrationError(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	host.Fail(uitest.SectionUser, errors.New("open /proc/812/status: permission denied"))
+	d := start(t, host)
+
+	d.Press("delete")
+	if got := state(d); got != StateHydrationsFinishedErrorDismissed {
+		t.Fatalf("state %d after delete, want the error dismissed", got)
+	}
+	dismissed := d.Frame()
+	uitest.Golden(t, "error_dismissed", dismissed)
+
+	// dismissing is not resolving: the data stays partial and there is nothing left to retry
+	host.Recover(uitest.SectionUser)
+	d.Press("r")
+	if got := d.Frame(); got != dismissed {
+		t.Errorf("retry after dismissal changed the screen:\n%s", got)
+	}
+}
+
+func TestProcessGone(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	host.Fail(uitest.SectionResource, syscall.ESRCH)
+	d := start(t, host)
+	if got := state(d); got != StateProcessGone {
+		t.Fatalf("state %d, want the process gone", got)
+	}
+	uitest.Golden(t, "process_gone", d.Frame())
+
+	d.Press("s")
+	if frame := d.Frame(); strings.Contains(frame, "Send Signal to Process") {
+		t.Errorf("signal modal opened for an exited process:\n%s", frame)
+	}
+}
+
+func TestSendSignal(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	d := start(t, host)
+	hydrated := d.Frame()
+
+	d.Press("s")
+	uitest.Golden(t, "signal_modal", d.Frame())
+
+	// SIGCONT, two below the preselected SIGTERM, cannot terminate anything and goes out without a confirmation
+	d.Press("down", "down", "enter")
+	if want := []uitest.Delivery{{PID: nginxPID, Signal: syscall.SIGCONT}}; !slices.Equal(host.Delivered, want) {
+		t.Errorf("delivered %v, want %v", host.Delivered, want)
+	}
+	uitest.Golden(t, "signal_sent", d.Frame())
+
+	d.Press("delete")
+	if got := d.Frame(); got != hydrated {
+		t.Errorf("frame after dismissing the notification:\n%s\nwant:\n%s", got, hydrated)
+	}
+}
+
+func TestConfirmSignal(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	host.Fail(uitest.SectionSignal, syscall.EPERM)
+	d := start(t, host)
+
+	d.Press("s", "enter")
+	uitest.Golden(t, "signal_confirm", d.Frame())
+
+	d.Press("esc")
+	if frame := d.Frame(); !strings.Contains(frame, "Send Signal to Process") {
+		t.Fatalf("esc on the confirmation should go back to the signal list:\n%s", frame)
+	}
+
+	d.Press("enter", "y")
+	uitest.Golden(t, "signal_denied", d.Frame())
+}
@@ -0,0 +1,86 @@
+package processdetail
+
+import (
+	"netps/internal/socket"
+	"netps/internal/ui/common"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Resource values compared between two samples, elapsed time is left out
+// because it changes on every sample by definition
+const (
+	resourceRSS   = "rss"
+	resourceVSZ   = "vsz"
+	resourceUTime = "utime"
+	resourceSTime = "stime"
+)
+
+// Direction of a value change since the previous sample: 1 grew, -1 shrank
+type resourceChanges map[string]int
+
+type socketChanges struct {
+	appeared map[uint64]bool
+	closed   []socket.Socket
+}
+
+// Ticks carry the sequence they were scheduled with, a tick from a previous
+// visit of this screen is dropped instead of doubling the refresh rate
+func ScheduleRefresh(clock common.Clock, interval time.Duration, seq int) tea.Cmd {
+	return clock.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{seq: seq}
+	})
+}
+
+func diffResource(old ResourceHydrationData, sample resourceHydratedMsg) resourceChanges {
+	changes := resourceChanges{}
+	setDirection(changes, resourceRSS, old.RSSByte < sample.RSSByte, old.RSSByte > sample.RSSByte)
+	setDirection(changes, resourceVSZ, old.VSZByte < sample.VSZByte, old.VSZByte > sample.VSZByte)
+	setDirection(changes, resourceUTime, old.UTime < sample.UTime, old.UTime > sample.UTime)
+	setDirection(changes, resourceSTime, old.STime < sample.STime, old.STime > sample.STime)
+	return changes
+}
+
+func setDirection(changes resourceChanges, key string, grew bool, shrank bool) {
+	if grew {
+		changes[key] = 1
+	} else if shrank {
+		changes[key] = -1
+	}
+}
+
+func diffSockets(old []socket.Socket, sample []socket.Socket) socketChanges {
+	oldInodes := map[uint64]bool{}
+	for _, s := range old {
+		oldInodes[s.Inode] = true
+	}
+	sampleInodes := map[uint64]bool{}
+	for _, s := range sample {
+		sampleInodes[s.Inode] = true
+	}
+
+	changes := socketChanges{appeared: map[uint64]bool{}}
+	for _, s := range sample {
+		if !oldInodes[s.Inode] {
+			changes.appeared[s.Inode] = true
+		}
+	}
+	for _, s := range old {
+		if !sampleInodes[s.Inode] {
+			changes.closed = append(changes.closed, s)
+		}
+	}
+	return changes
+}
+
+func changeArrow(direction int) string {
+	switch direction {
+	case 1:
+		return " ▲"
+	case -1:
+		return " ▼"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,50 @@
+package processdetail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"netps/internal/process"
+	"netps/internal/ui/common"
+	"syscall"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func DeliverSignal(ctx context.Context, pid int, sig process.Signal, processService *process.Service) tea.Cmd {
+	return func() tea.Msg {
+		if ctx.Err() != nil {
+			return signalDeliveredMsg{Signal: sig, PID: pid, Err: ctx.Err()}
+		}
+
+		err := processService.SendSignal(ctx, pid, sig.Number)
+		return signalDeliveredMsg{Signal: sig, PID: pid, Err: err}
+	}
+}
+
+// Maps the result of a signal delivery into a notification the user can act on.
+// EPERM and ESRCH are the expected failures, anything else is shown verbatim
+func signalNotification(sig process.Signal, pid int, name string, err error) common.Notification {
+	switch {
+	case err == nil:
+		return common.Notification{
+			ColorMode: common.ColorModeSuccess,
+			Info:      fmt.Sprintf("%s sent to %s (%d)", sig.Name, name, pid),
+		}
+	case errors.Is(err, syscall.EPERM):
+		return common.Notification{
+			ColorMode: common.ColorModeDanger,
+			Info:      fmt.Sprintf("Permission denied: cannot send %s to %s (%d)", sig.Name, name, pid),
+		}
+	case errors.Is(err, syscall.ESRCH):
+		return common.Notification{
+			ColorMode: common.ColorModeWarning,
+			Info:      fmt.Sprintf("%s not sent: process %d no longer exists", sig.Name, pid),
+		}
+	default:
+		return common.Notification{
+			ColorMode: common.ColorModeDanger,
+			Info:      fmt.Sprintf("Failed to send %s to %s (%d): %v", sig.Name, name, pid, err),
+		}
+	}
+}
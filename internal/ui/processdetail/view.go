@@ -157,6 +157,8 @@ func processDetailSection(
 	elapsedTime time.Duration,
 	uTime time.Duration,
 	sTime time.Duration,
+	resourceChanges resourceChanges,
+	socketChanges socketChanges,
 ) string {
 
 	baseForegroundColor := lipgloss.Color(theme.ColorForegroundBase) // COlorWhite
@@ -231,12 +233,35 @@ func processDetailSection(
 	commandSection := normalList(active, theme, lipgloss.Color(theme.ColorInactive), "Command", []string{subtleForegroundText(command)})
 
 	socketItems := []string{}
+	unixSocketItems := []string{}
+	unixListenCount, unixConnectedCount := 0, 0
 	for _, s := range sockets {
-		socketItems = append(socketItems, formatSocketText(s, listenSocketItem, establishedSocketItem, closedSocketItem))
+		if s.IsUnix() {
+			unixSocketItems = append(unixSocketItems, formatSocketText(s, pid, socketChanges.appeared[s.Inode], listenSocketItem, establishedSocketItem, closedSocketItem))
+			switch s.State {
+			case socket.StateListen:
+				unixListenCount++
+			case socket.StateEstablished:
+				unixConnectedCount++
+			}
+			continue
+		}
+		socketItems = append(socketItems, formatSocketText(s, pid, socketChanges.appeared[s.Inode], listenSocketItem, establishedSocketItem, closedSocketItem))
+	}
+	// closed since the previous sample, shown once so the disappearance is noticed
+	for _, s := range socketChanges.closed {
+		text := closedSocketItem(fmt.Sprintf("%s %s (%s)", s.ProtoLabel(), s.Endpoints(), "GONE"))
+		if s.IsUnix() {
+			unixSocketItems = append(unixSocketItems, text)
+		} else {
+			socketItems = append(socketItems, text)
+		}
 	}
 	aggregated := socket.Aggregate(sockets)
-	socket := fmt.Sprintf("Sockets · %dL %dE %dC (%d)", aggregated.ListenCount, aggregated.EstablishedCount, aggregated.CloseCount, len(socketItems))
-	socketSection := normalList(active, theme, lipgloss.Color(theme.ColorInactive), socket, socketItems)
+	socketHeader := fmt.Sprintf("Sockets · %dL %dE %dC (%d)", aggregated.ListenCount, aggregated.EstablishedCount, aggregated.CloseCount, len(socketItems))
+	socketSection := normalList(active, theme, lipgloss.Color(theme.ColorInactive), socketHeader, socketItems)
+	unixSocketHeader := fmt.Sprintf("Unix Sockets · %dL %dE (%d)", unixListenCount, unixConnectedCount, aggregated.UnixCount)
+	unixSocketSection := normalList(active, theme, lipgloss.Color(theme.ColorInactive), unixSocketHeader, unixSocketItems)
 
 	ownerShipLabels := []string{"User", "Privilege"}
 	ownerShipValues := []string{
@@ -252,19 +277,19 @@ func processDetailSection(
 		"User Time",
 		"System Time"}
 	resourceValues := []string{
-		fmt.Sprintf("%d Bytes", rssByte),
-		fmt.Sprintf("%d Bytes", vszByte),
+		fmt.Sprintf("%d Bytes%s", rssByte, changeArrow(resourceChanges[resourceRSS])),
+		fmt.Sprintf("%d Bytes%s", vszByte, changeArrow(resourceChanges[resourceVSZ])),
 		util.DurationToHHMMSS(startTime),
 		util.DurationToHHMMSS(elapsedTime),
-		util.DurationToHHMMSS(uTime),
-		util.DurationToHHMMSS(sTime)}
+		util.DurationToHHMMSS(uTime) + changeArrow(resourceChanges[resourceUTime]),
+		util.DurationToHHMMSS(sTime) + changeArrow(resourceChanges[resourceSTime])}
 	resourceSection := labeledList(active, theme, lipgloss.Color(theme.ColorInactive), "Resources", resourceLabels, resourceValues)
 
 	firstSection := verticalGroup(staticIdSection, commandSection)
 	secondSection := horizontalGroup(
 		theme,
 		verticalGroup(resourceSection, ownerSection),
-		verticalGroup(socketSection),
+		verticalGroup(socketSection, unixSocketSection),
 	)
 
 	ui := lipgloss.NewStyle().
@@ -276,15 +301,43 @@ func processDetailSection(
 	return ui
 }
 
-func formatSocketText(sock socket.Socket, lStyle styleFunc, eStyle styleFunc, cStyle styleFunc) string {
+func formatSocketText(sock socket.Socket, pid int, appeared bool, lStyle styleFunc, eStyle styleFunc, cStyle styleFunc) string {
+	shared := formatSharedWith(sock.OtherOwnerPIDs(pid))
+	if appeared {
+		shared += " · new"
+	}
 	text := ""
 	switch sock.State {
 	case socket.StateListen:
-		text = lStyle(fmt.Sprintf("%s %s:%d (%s)", sock.Proto, sock.Addr, sock.Port, "LISTEN"))
+		text = lStyle(fmt.Sprintf("%s %s (%s)%s", sock.ProtoLabel(), sock.Endpoints(), "LISTEN", shared))
 	case socket.StateEstablished:
-		text = eStyle(fmt.Sprintf("%s %s:%d (%s)", sock.Proto, sock.Addr, sock.Port, "ESTABLISHED"))
+		status := "ESTABLISHED"
+		if sock.TCPInfo != nil {
+			status = fmt.Sprintf("ESTABLISHED, rtt %s", sock.TCPInfo.RTT)
+		}
+		text = eStyle(fmt.Sprintf("%s %s (%s)%s", sock.ProtoLabel(), sock.Endpoints(), status, shared))
 	case socket.StateClose:
-		text = cStyle(fmt.Sprintf("%s %s:%d (%s)", sock.Proto, sock.Addr, sock.Port, "CLOSE"))
+		text = cStyle(fmt.Sprintf("%s %s (%s)%s", sock.ProtoLabel(), sock.Endpoints(), "CLOSE", shared))
+	case socket.StateUnconnected:
+		text = cStyle(fmt.Sprintf("%s %s (%s)%s", sock.ProtoLabel(), sock.Endpoints(), "UNCONNECTED", shared))
+	}
+	return text
+}
+
+// maxSharedPIDs caps how many other holders are spelled out per socket
+const maxSharedPIDs = 3
+
+func formatSharedWith(pids []int) string {
+	if len(pids) == 0 {
+		return ""
+	}
+	shown := []string{}
+	for _, p := range pids[:min(len(pids), maxSharedPIDs)] {
+		shown = append(shown, strconv.Itoa(p))
+	}
+	text := " · shared with " + strings.Join(shown, ", ")
+	if len(pids) > maxSharedPIDs {
+		text += fmt.Sprintf(" +%d more", len(pids)-maxSharedPIDs)
 	}
 	return text
 }
@@ -292,3 +345,19 @@ func formatSocketText(sock socket.Socket, lStyle styleFunc, eStyle styleFunc, cS
 func scrollingInfo(scrollingPercent float64, visibleContentPercent float64) string {
 	return fmt.Sprintf("scrolling %3.f%% · showing %3.f%%", scrollingPercent, visibleContentPercent)
 }
+
+// Replaces every section once the process exited, its last sample would only mislead
+func processGoneSection(theme common.Theme, width int, name string, pid int) string {
+	baseForegroundStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.ColorForegroundBase))
+	subtleForegroundStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(theme.ColorForegroundSubtle))
+
+	return lipgloss.NewStyle().
+		Width(width - baseForegroundStyle.GetHorizontalFrameSize()).
+		MarginTop(theme.SpacingSmall).
+		Render(
+			lipgloss.JoinVertical(lipgloss.Left,
+				baseForegroundStyle.Render(fmt.Sprintf("%s (%d) is gone", name, pid)),
+				subtleForegroundStyle.Render("The process exited or its PID now belongs to another process."),
+			),
+		)
+}
@@ -0,0 +1,26 @@
+package processlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"netps/internal/process"
+	"os"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Writes the summaries as JSON into the working directory, the file name carries a timestamp.
+// Same document as `netps list --format json`
+func ExportSummaries(summaries []process.ProcessSummary, now time.Time) tea.Cmd {
+	return func() tea.Msg {
+		path := fmt.Sprintf("netps-export-%s.json", now.Format("20060102-150405"))
+
+		data, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return exportedMsg{Err: err}
+		}
+		err = os.WriteFile(path, append(data, '\n'), 0o644)
+		return exportedMsg{Path: path, Count: len(summaries), Err: err}
+	}
+}
@@ -0,0 +1,53 @@
+package processlist
+
+import (
+	"netps/internal/process"
+	"strconv"
+	"strings"
+)
+
+// Every whitespace separated term has to match, a term matches when it is
+//   - ":<port>", a listening port
+//   - a number, a PID prefix or a listening port
+//   - a socket protocol (tcp, udp6, unix, ...)
+//   - part of the process name, case insensitive
+func matchesFilter(p process.ProcessSummary, query string) bool {
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !matchesTerm(p, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesTerm(p process.ProcessSummary, term string) bool {
+	if port, ok := strings.CutPrefix(term, ":"); ok {
+		n, err := strconv.Atoi(port)
+		return err == nil && p.ListensOn(n)
+	}
+
+	if n, err := strconv.Atoi(term); err == nil {
+		if strings.HasPrefix(strconv.Itoa(p.PID), term) || p.ListensOn(n) {
+			return true
+		}
+	}
+
+	if p.HasProto(term) {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(p.Name), term)
+}
+
+func filterSummaries(summaries []process.ProcessSummary, query string) []process.ProcessSummary {
+	if strings.TrimSpace(query) == "" {
+		return summaries
+	}
+	filtered := []process.ProcessSummary{}
+	for _, p := range summaries {
+		if matchesFilter(p, query) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
@@ -3,8 +3,8 @@ package processlist
 import (
 	"context"
 	"netps/internal/process"
-	"netps/internal/procfs"
-	"netps/internal/sysconf"
+	"netps/internal/ui/common"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 )
@@ -18,21 +18,8 @@ func InitWindow(w, h int) tea.Cmd {
 	}
 }
 
-func HydrateRunningProcesses(ctx context.Context) tea.Cmd {
+func HydrateRunningProcesses(ctx context.Context, service *process.Service) tea.Cmd {
 	return func() tea.Msg {
-		procfsClient := procfs.NewClient()
-		sysconfClient := sysconf.NewClient()
-
-		cfg := process.Config{
-			Process:   procfsClient,
-			Detail:    procfsClient,
-			Clocktick: sysconfClient,
-			PageSize:  sysconfClient,
-			UpTime:    procfsClient,
-			Resource:  procfsClient,
-			User:      procfsClient,
-		}
-		service := process.NewProcessService(cfg)
 		processSummaries, err := service.GetRunningSummaries(ctx)
 		if err != nil {
 			return hydrationErrorMsg{Error: err}
@@ -42,3 +29,11 @@ func HydrateRunningProcesses(ctx context.Context) tea.Cmd {
 		}
 	}
 }
+
+// Ticks carry the sequence they were scheduled with so that a chain started
+// before leaving the screen dies out instead of doubling the refresh rate
+func ScheduleRefresh(clock common.Clock, interval time.Duration, seq int) tea.Cmd {
+	return clock.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{seq: seq}
+	})
+}
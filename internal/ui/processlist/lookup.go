@@ -0,0 +1,105 @@
+package processlist
+
+import (
+	"context"
+	"fmt"
+	"netps/internal/socket"
+	"netps/internal/ui/common"
+	"netps/internal/ui/message"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func LookupPort(ctx context.Context, q socket.PortQuery, socketService *socket.Service) tea.Cmd {
+	return func() tea.Msg {
+		sockets, err := socketService.FindBound(ctx, q)
+		return portLookedUpMsg{Query: q, Sockets: sockets, Err: err}
+	}
+}
+
+func (m Model) updatePortInput(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.portInput, cmd = m.portInput.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) openPortLookup() {
+	m.lookingUp = true
+	m.mode = modePortLookup
+	m.modeColor = common.ColorModeSpecial
+	m.portInput.Reset()
+	m.portInput.Focus()
+	m.updateTableSize(m.width, m.height)
+}
+
+func (m *Model) closePortLookup() {
+	m.lookingUp = false
+	m.mode = modeProcessList
+	m.modeColor = common.ColorModeNeutral
+	m.portInput.Blur()
+	m.updateTableSize(m.width, m.height)
+}
+
+// A bad query keeps the input open so it can be corrected
+func (m Model) applyPortLookup() (Model, tea.Cmd) {
+	q, err := socket.ParsePortQuery(m.portInput.Value())
+	if err != nil {
+		m.notify(common.ColorModeDanger, err.Error())
+		return m, nil
+	}
+	m.closePortLookup()
+	return m, LookupPort(m.ctx, q, m.socketService)
+}
+
+// A single owner opens its detail screen, several are selected for bulk actions
+func (m Model) handlePortLookedUp(msg portLookedUpMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.notify(common.ColorModeDanger, fmt.Sprintf("Port lookup failed: %v", msg.Err))
+		return m, nil
+	}
+
+	owners := []message.ProcessRef{}
+	seen := map[int]bool{}
+	for _, sock := range msg.Sockets {
+		for _, pid := range sock.OwnerPIDs() {
+			if !seen[pid] {
+				seen[pid] = true
+				owners = append(owners, message.ProcessRef{PID: pid, Name: m.processName(pid)})
+			}
+		}
+	}
+
+	switch len(owners) {
+	case 0:
+		m.notify(common.ColorModeWarning, fmt.Sprintf("Nothing bound to %s", msg.Query))
+		return m, nil
+	case 1:
+		m.notification = nil
+		return m, func() tea.Msg {
+			return message.GoToProcessDetail{PID: owners[0].PID, Name: owners[0].Name}
+		}
+	}
+
+	m.selected = map[int]bool{}
+	for _, o := range owners {
+		m.selected[o.PID] = true
+	}
+	m.rebuildRows()
+	m.selectPID(owners[0].PID)
+	m.notify(common.ColorModeNeutral, fmt.Sprintf("%s is held by %d processes, selected", msg.Query, len(owners)))
+	return m, nil
+}
+
+func (m Model) processName(pid int) string {
+	for _, p := range m.processSummaries {
+		if p.PID == pid {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+func (m *Model) notify(colorMode common.ColorMode, info string) {
+	m.notification = &common.Notification{ColorMode: colorMode, Info: info}
+	m.updateTableSize(m.width, m.height)
+}
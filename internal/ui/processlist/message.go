@@ -1,6 +1,9 @@
 package processlist
 
-import "netps/internal/process"
+import (
+	"netps/internal/process"
+	"netps/internal/socket"
+)
 
 type initMsg struct {
 	Width, Height int
@@ -13,3 +16,24 @@ type processSummariesLoadedMsg struct {
 type hydrationErrorMsg struct {
 	Error error
 }
+
+type refreshTickMsg struct {
+	seq int
+}
+
+type signalsDeliveredMsg struct {
+	Signal  process.Signal
+	Results []signalResult
+}
+
+type exportedMsg struct {
+	Path  string
+	Count int
+	Err   error
+}
+
+type portLookedUpMsg struct {
+	Query   socket.PortQuery
+	Sockets []socket.Socket
+	Err     error
+}
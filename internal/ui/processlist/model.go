@@ -1,8 +1,12 @@
 // Invariants:
-// 1. This model hydrates exactly once.
+// 1. This model hydrates on init and then on every refresh tick unless paused.
+//    Rows follow the chosen sort order with PID as tie-breaker, so a refresh never reshuffles equal rows.
 // 2. Table initialization happens on first WindowSizeMsg.
 // 3. Focus is forced after hydration to ensure width recalculation is rendered.
-// 4. This screen does not preserve selection across resizes.
+// 4. Selection is preserved by PID across refreshes and filter changes, not across resizes.
+// 5. The table shows visibleSummaries, processSummaries always holds the unfiltered list.
+// 6. Bulk actions (signal, export, sockets) apply to the marked rows, or to the cursor row when none is marked.
+// 7. A port lookup opens the detail screen of a single owner and marks the rows of several.
 
 package processlist
 
@@ -10,15 +14,21 @@ import (
 	"context"
 	"fmt"
 	"netps/internal/process"
+	"netps/internal/socket"
 	"netps/internal/ui/common"
 	"netps/internal/ui/common/command"
+	"netps/internal/ui/common/sendsignal"
 	"netps/internal/ui/message"
+	"slices"
 
 	"strconv"
+	"strings"
+	"time"
 
 	"log"
 
 	"charm.land/bubbles/v2/table"
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 )
@@ -26,19 +36,51 @@ import (
 const HorizontalPadding = 1
 const VerticalPadding = 2
 
+// Base column titles, the sort indicator is appended to one of them
+var columnTitles = []string{"", "PID", "NAME", "SOCKS", "L.PORTS"}
+
+const modeProcessList = "Process List"
+const modeFilter = "Filter"
+const modeSendSignal = "Send Signal"
+const modeConfirmSignal = "Confirm Signal"
+const modePortLookup = "Port Lookup"
+
 type Model struct {
 	processSummaries []process.ProcessSummary
+	visibleSummaries []process.ProcessSummary
 	table            table.Model
+	filterInput      textinput.Model
+	filtering        bool // typing into the filter bar
+	portInput        textinput.Model
+	lookingUp        bool // typing a port to jump to
+	order            sortOrder
+	selected         map[int]bool // marked for bulk actions, by PID
+	signalModal      sendsignal.Model
+	pendingSignal    process.Signal
+	pendingTargets   []message.ProcessRef
+	notification     *common.Notification
 	ctx              context.Context
 	cancel           context.CancelFunc
 	width, height    int
 	mode             string
 	modeColor        common.ColorMode
 	theme            common.Theme
+	clock            common.Clock
 	commandManager   *command.Manager
+	processService   *process.Service
+	socketService    *socket.Service
+
+	refreshInterval time.Duration // zero disables auto-refresh
+	snapshotAt      time.Time     // set when replaying a snapshot instead of reading the host
+	refreshSeq      int
+	refreshing      bool
+	refreshErr      error
+	paused          bool
+	hydrated        bool
+	highlights      map[int]rowHighlight
 }
 
-func New(theme common.Theme, commandManager *command.Manager) (Model, error) {
+func New(theme common.Theme, clock common.Clock, commandManager *command.Manager, processService *process.Service, socketService *socket.Service) (Model, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	err := commandManager.SetContext(command.ContextProcessListScreen)
@@ -53,15 +95,42 @@ func New(theme common.Theme, commandManager *command.Manager) (Model, error) {
 		return Model{}, err
 	}
 
+	filterInput := common.NewTextInput(clock)
+	filterInput.Prompt = "filter: "
+	filterInput.Placeholder = "pid, name, :port or protocol"
+
+	portInput := common.NewTextInput(clock)
+	portInput.Prompt = "port: "
+	portInput.Placeholder = "8080, udp 53 or 127.0.0.1:8080"
+
+	signalModal := sendsignal.New(clock)
+	signalModal.Initialize()
+
 	return Model{
-		mode:           "Process List",
+		mode:           modeProcessList,
+		filterInput:    filterInput,
+		portInput:      portInput,
+		signalModal:    signalModal,
 		ctx:            ctx,
 		cancel:         cancel,
 		theme:          theme,
+		clock:          clock,
 		commandManager: commandManager,
+		processService: processService,
+		socketService:  socketService,
 	}, nil
 }
 
+func (m Model) WithRefreshInterval(interval time.Duration) Model {
+	m.refreshInterval = interval
+	return m
+}
+
+func (m Model) WithSnapshot(capturedAt time.Time) Model {
+	m.snapshotAt = capturedAt
+	return m
+}
+
 func registerContextualCommands(commandManager *command.Manager) error {
 	err := commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyUp, command.CommandMove)
 	if err != nil {
@@ -79,13 +148,68 @@ func registerContextualCommands(commandManager *command.Manager) error {
 	if err != nil {
 		return err
 	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyP, command.CommandPause)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyF, command.CommandFilter)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyO, command.CommandOrder)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyR, command.CommandReverse)
+	if err != nil {
+		return err
+	}
+
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyW, command.CommandFindPort)
+	if err != nil {
+		return err
+	}
+
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyM, command.CommandMultipleSelect)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyE, command.CommandExport)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyV, command.CommandViewSockets)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextProcessListScreen, command.KeyDel, command.CommandDismiss)
+	if err != nil {
+		return err
+	}
+
+	err = commandManager.RegisterContextCommand(command.ContextFilter, command.KeyUp, command.CommandMove)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextFilter, command.KeyDown, command.CommandMove)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextFilter, command.KeyEnter, command.CommandApply)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextPortLookup, command.KeyEnter, command.CommandApply)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
 func (m Model) Init(w, h int) tea.Cmd {
 	return tea.Batch(
 		InitWindow(w, h),
-		HydrateRunningProcesses(m.ctx),
+		HydrateRunningProcesses(m.ctx, m.processService),
 	)
 }
 
@@ -102,26 +226,185 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case initMsg:
 		m.updateWindowSize(msg.Width, msg.Height)
 		m.updateTableSize(m.width, m.height) // need to update so that it recalculates table size after back from detail screen
+		if m.refreshInterval > 0 {
+			m.refreshSeq++ // orphans the tick chain of a previous visit
+			return m, ScheduleRefresh(m.clock, m.refreshInterval, m.refreshSeq)
+		}
 	case processSummariesLoadedMsg:
-		m.updateTableRows(msg.ProcessSummaries)
+		selectedPID := m.selectedPID()
+		m.updateTableRows(m.mergeSummaries(msg.ProcessSummaries))
+		m.selectPID(selectedPID)
+		m.hydrated = true
+		m.refreshing = false
+		m.refreshErr = nil
 		m.updateTableSize(m.width, m.height)
 		m.table.Focus() // Safe to auto-focus: if not, the table won't update the screen with the new width from updateTableSize unless you resize the terminal
 	case hydrationErrorMsg:
+		if m.hydrated {
+			// a failed refresh keeps the last good list on screen
+			m.refreshing = false
+			m.refreshErr = msg.Error
+			return m, nil
+		}
 		m.cancel()
 		return m, tea.Quit // might later add an error view. No action needed now.
+	case refreshTickMsg:
+		if msg.seq != m.refreshSeq {
+			return m, nil
+		}
+		selectedPID := m.selectedPID()
+		m.ageHighlights()
+		m.pruneSelection()
+		m.updateTableRows(m.processSummaries)
+		m.selectPID(selectedPID)
+
+		cmds := []tea.Cmd{ScheduleRefresh(m.clock, m.refreshInterval, m.refreshSeq)}
+		if !m.paused && !m.refreshing {
+			m.refreshing = true
+			cmds = append(cmds, HydrateRunningProcesses(m.ctx, m.processService))
+		}
+		return m, tea.Batch(cmds...)
+	case signalsDeliveredMsg:
+		notification := signalsNotification(msg.Signal, msg.Results)
+		m.notification = &notification
+		m.updateTableSize(m.width, m.height)
+		return m, nil
+	case exportedMsg:
+		notification := common.Notification{
+			ColorMode: common.ColorModeSuccess,
+			Info:      fmt.Sprintf("Exported %d %s to %s", msg.Count, pluralProcess(msg.Count), msg.Path),
+		}
+		if msg.Err != nil {
+			notification = common.Notification{
+				ColorMode: common.ColorModeDanger,
+				Info:      fmt.Sprintf("Export failed: %v", msg.Err),
+			}
+		}
+		m.notification = &notification
+		m.updateTableSize(m.width, m.height)
+		return m, nil
+	case portLookedUpMsg:
+		return m.handlePortLookedUp(msg)
 	case tea.KeyMsg:
+		// typed characters go to the input bar, they must not trigger commands
+		if press, ok := msg.(tea.KeyPressMsg); ok && common.CapturesKey(press) {
+			if m.lookingUp {
+				return m.updatePortInput(msg)
+			}
+			if m.filtering {
+				return m.updateFilter(msg)
+			}
+		}
+
+		if m.mode == modeSendSignal || m.mode == modeConfirmSignal {
+			return m.updateSignalModal(msg)
+		}
+
 		c := m.commandManager.GetCommand(command.ToKeyPress(msg.String()))
 
 		switch c {
+		case command.CommandMultipleSelect:
+			m.toggleSelection(m.selectedPID())
+			m.rebuildRows()
+			return m, nil
+		case command.CommandSendSignal:
+			targets := m.actionTargets()
+			if len(targets) == 0 {
+				return m, nil
+			}
+			m.pendingTargets = targets
+			m.mode = modeSendSignal
+			m.modeColor = common.ColorModeSpecial
+			m.signalModal.Open()
+			m.mustSetCommandContext()
+			return m, nil
+		case command.CommandExport:
+			summaries := m.selectedSummaries()
+			if len(summaries) == 0 {
+				return m, nil
+			}
+			return m, ExportSummaries(summaries, time.Now())
+		case command.CommandViewSockets:
+			targets := m.actionTargets()
+			if len(targets) == 0 {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return message.GoToSocketView{Processes: targets}
+			}
+		case command.CommandDismiss:
+			m.notification = nil
+			m.updateTableSize(m.width, m.height)
+			return m, nil
+		case command.CommandFilter:
+			m.filtering = true
+			m.mode = modeFilter
+			m.modeColor = common.ColorModeSpecial
+			m.filterInput.Focus()
+			m.updateTableSize(m.width, m.height)
+			m.mustSetCommandContext()
+			return m, nil
+		case command.CommandFindPort:
+			m.openPortLookup()
+			m.mustSetCommandContext()
+			return m, nil
+		case command.CommandOrder:
+			m.order = m.order.next()
+			m.updateColumnTitles()
+			m.rebuildRows()
+			return m, nil
+		case command.CommandReverse:
+			m.order = m.order.reversed()
+			m.updateColumnTitles()
+			m.rebuildRows()
+			return m, nil
+		case command.CommandApply:
+			if m.lookingUp {
+				m, cmd = m.applyPortLookup()
+				m.mustSetCommandContext()
+				return m, cmd
+			}
+			m.stopFiltering()
+			m.mustSetCommandContext()
+			return m, nil
+		case command.CommandBack:
+			if m.lookingUp {
+				m.closePortLookup()
+				m.mustSetCommandContext()
+				return m, nil
+			}
+			// esc drops the filter, whether it is still being typed or already applied
+			if m.filtering || m.filterInput.Value() != "" {
+				m.filterInput.Reset()
+				m.stopFiltering()
+				m.rebuildRows()
+				m.mustSetCommandContext()
+				return m, nil
+			}
+			if len(m.selected) > 0 {
+				m.selected = nil
+				m.rebuildRows()
+				return m, nil
+			}
 		case command.CommandQuit:
 			m.cancel()
 			return m, tea.Quit
+		case command.CommandPause:
+			if m.refreshInterval == 0 {
+				return m, nil
+			}
+			m.paused = !m.paused
+			if !m.paused && !m.refreshing {
+				m.refreshing = true
+				return m, HydrateRunningProcesses(m.ctx, m.processService)
+			}
+			return m, nil
 		case command.CommandInspect:
 			row := m.table.SelectedRow()
 			if len(row) == 0 {
 				return m, nil
 			}
-			pid, err := strconv.Atoi(row[0])
+			pid, err := strconv.Atoi(row[1])
 			if err != nil {
 				return m, func() tea.Msg {
 					return hydrationErrorMsg{Error: err}
@@ -130,20 +413,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, func() tea.Msg {
 				return message.GoToProcessDetail{
 					PID:  pid,
-					Name: row[1],
+					Name: row[2],
 				}
 			}
 		}
 	}
 
-	err := m.setCurrentCommandContext()
-	if err != nil {
-		log.Fatalf("Process Detail Model Error: %v", err)
-	}
+	m.mustSetCommandContext()
 
-	if m.mode == "Process List" {
+	switch m.mode {
+	case modeProcessList:
 		m.modeColor = common.ColorModeNeutral
-	} else {
+	case modeConfirmSignal:
+		m.modeColor = common.ColorModeWarning
+	default:
 		m.modeColor = common.ColorModeSpecial
 	}
 
@@ -155,21 +438,84 @@ func (m Model) View() tea.View {
 	var baseStyle = lipgloss.NewStyle().
 		BorderStyle(lipgloss.NormalBorder()).
 		BorderForeground(lipgloss.Color("240"))
-	processCount := fmt.Sprintf("showing %d from %d processes", m.getShowingProcessCount(), len(m.processSummaries))
-	statusBar := common.StatusBar(m.theme, m.width, m.mode, m.modeColor, processCount, "", common.ColorModeNeutral)
-	actionBar := common.ActionBar(m.width, m.commandManager.GenerateContextHelp())
-	v := tea.NewView(baseStyle.Render(m.table.View()) + "\n" + statusBar + "\n" + actionBar + "\n")
+	statusBar := m.statusBar()
+	actionBar := common.ActionBar(m.width, m.helpItems())
+	content := baseStyle.Render(m.table.View()) + "\n"
+	if m.showFilterBar() {
+		content += m.filterInput.View() + "\n"
+	}
+	if m.lookingUp {
+		content += m.portInput.View() + "\n"
+	}
+	if m.notification != nil {
+		content += common.NotificationBar(m.theme, m.notification.ColorMode, m.width, m.notification.Info) + "\n"
+	}
+	content += statusBar + "\n" + actionBar + "\n"
+
+	var v tea.View
+	switch m.mode {
+	case modeSendSignal:
+		v = tea.NewView(lipgloss.NewCanvas(lipgloss.NewLayer(content), m.modalLayer(m.signalModal.Modal)))
+	case modeConfirmSignal:
+		v = tea.NewView(lipgloss.NewCanvas(lipgloss.NewLayer(content), m.modalLayer(m.confirmModal())))
+	default:
+		v = tea.NewView(content)
+	}
 	v.AltScreen = true
 	return v
 }
 
-func mapProcessItem(processSummaries []process.ProcessSummary) []table.Row {
+func (m Model) statusBar() string {
+	info := fmt.Sprintf("showing %d from %d processes", len(m.visibleSummaries), len(m.processSummaries))
+	if len(m.selected) > 0 {
+		info += fmt.Sprintf(" · %d selected", len(m.selected))
+	}
+	if legend := m.sharedLegend(); legend != "" {
+		info += " · " + legend
+	}
+
+	refreshInfo, refreshColor := "", common.ColorModeNeutral
+	switch {
+	case !m.snapshotAt.IsZero():
+		refreshInfo, refreshColor = "snapshot "+m.snapshotAt.Local().Format(time.DateTime), common.ColorModeWarning
+	case m.refreshInterval == 0:
+	case m.refreshErr != nil:
+		refreshInfo, refreshColor = "refresh failed", common.ColorModeWarning
+	case m.paused:
+		refreshInfo, refreshColor = "paused", common.ColorModeWarning
+	default:
+		refreshInfo = fmt.Sprintf("every %s", m.refreshInterval)
+	}
+	return common.StatusBar(m.theme, m.width, m.mode, m.modeColor, info, refreshInfo, refreshColor)
+}
+
+func (m Model) selectedPID() int {
+	cursor := m.table.Cursor()
+	if cursor < 0 || cursor >= len(m.visibleSummaries) {
+		return -1
+	}
+	return m.visibleSummaries[cursor].PID
+}
+
+// Moves the cursor back onto pid, stays put (clamped) when it is gone from the list
+func (m *Model) selectPID(pid int) {
+	for i, p := range m.visibleSummaries {
+		if p.PID == pid {
+			m.table.SetCursor(i)
+			return
+		}
+	}
+	m.table.SetCursor(m.table.Cursor())
+}
+
+func (m Model) mapProcessItem(processSummaries []process.ProcessSummary) []table.Row {
 	var rows []table.Row
 	for _, p := range processSummaries {
 		r := table.Row{
+			m.selectionMarker(p.PID) + m.changeMarker(p.PID),
 			strconv.Itoa(p.PID),
 			p.Name,
-			formatSocketText(p.LSocketCount, p.ESocketCount, p.CSocketCount),
+			formatSocketText(p.LSocketCount, p.ESocketCount, p.CSocketCount, p.USocketCount),
 			p.LPortsText,
 		}
 		rows = append(rows, r)
@@ -177,8 +523,18 @@ func mapProcessItem(processSummaries []process.ProcessSummary) []table.Row {
 	return rows
 }
 
-func formatSocketText(lCount int, eCount int, cCount int) string {
-	return fmt.Sprintf("%dL %dE %dC", lCount, eCount, cCount)
+func formatSocketText(lCount int, eCount int, cCount int, uCount int) string {
+	return fmt.Sprintf("%dL %dE %dC %dU", lCount, eCount, cCount, uCount)
+}
+
+// Explains the port marker, only shown when some listener is actually shared
+func (m Model) sharedLegend() string {
+	for _, p := range m.processSummaries {
+		if strings.Contains(p.LPortsText, process.SharedMarker) {
+			return process.SharedMarker + " shared with other processes"
+		}
+	}
+	return ""
 }
 
 func (m *Model) updateWindowSize(w int, h int) {
@@ -189,10 +545,17 @@ func (m *Model) updateWindowSize(w int, h int) {
 func (m *Model) updateTableSize(newWidth int, newHeight int) {
 	newTableWidth := newWidth - (HorizontalPadding * (len(m.table.Columns()) - 1))
 	m.table.SetWidth(newTableWidth)
-	processCount := fmt.Sprintf("showing %d from %d processes", m.getShowingProcessCount(), len(m.processSummaries))
-
-	statusBarHeight := lipgloss.Height(common.StatusBar(m.theme, m.width, m.mode, m.modeColor, processCount, "", common.ColorModeNeutral))
-	actionBarHeight := lipgloss.Height(common.ActionBar(m.width, m.commandManager.GenerateContextHelp()))
+	statusBarHeight := lipgloss.Height(m.statusBar())
+	if m.showFilterBar() {
+		statusBarHeight += lipgloss.Height(m.filterInput.View())
+	}
+	if m.lookingUp {
+		statusBarHeight += lipgloss.Height(m.portInput.View())
+	}
+	if m.notification != nil {
+		statusBarHeight += lipgloss.Height(common.NotificationBar(m.theme, m.notification.ColorMode, m.width, m.notification.Info))
+	}
+	actionBarHeight := lipgloss.Height(common.ActionBar(m.width, m.helpItems()))
 	m.table.SetHeight(newHeight - VerticalPadding - statusBarHeight - actionBarHeight)
 
 	maxFieldLenghts := maxFieldLengths(m.processSummaries)
@@ -204,18 +567,16 @@ func (m *Model) updateTableSize(newWidth int, newHeight int) {
 
 	for i := 0; i < len(m.table.Columns())-1; i++ {
 		title := m.table.Columns()[i].Title
-		m.table.Columns()[i].Width = maxFieldLenghts[title]
+		m.table.Columns()[i].Width = max(maxFieldLenghts[columnTitles[i]], lipgloss.Width(title))
 	}
 
 	m.table.Columns()[len(m.table.Columns())-1].Width = lastColumnWidth
 }
 
 func (m *Model) initProcessTable() table.Model {
-	columns := []table.Column{
-		{Title: "PID"},
-		{Title: "NAME"},
-		{Title: "SOCKS"},
-		{Title: "L.PORTS"},
+	columns := []table.Column{}
+	for _, title := range columnTitles {
+		columns = append(columns, table.Column{Title: m.columnTitle(title)}) // the first one shows +/- while a process appeared or vanished
 	}
 	t := table.New(
 		table.WithColumns(columns),
@@ -236,14 +597,64 @@ func (m *Model) initProcessTable() table.Model {
 	return t
 }
 
+func (m Model) columnTitle(title string) string {
+	if sortColumn, _ := m.order.column(); title == sortColumn {
+		return title + m.order.indicator()
+	}
+	return title
+}
+
+func (m *Model) updateColumnTitles() {
+	if len(m.table.Columns()) == 0 {
+		return // table is created on the first WindowSizeMsg
+	}
+	for i, title := range columnTitles {
+		m.table.Columns()[i].Title = m.columnTitle(title)
+	}
+	m.updateTableSize(m.width, m.height)
+}
+
 func (m *Model) updateTableRows(summaries []process.ProcessSummary) {
 	m.processSummaries = summaries
-	rows := mapProcessItem(summaries)
+	m.visibleSummaries = slices.Clone(filterSummaries(summaries, m.filterInput.Value()))
+	sortSummaries(m.visibleSummaries, m.order)
+	rows := m.mapProcessItem(m.visibleSummaries)
 	m.table.SetRows(rows)
 }
 
+func (m Model) updateFilter(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	before := m.filterInput.Value()
+	m.filterInput, cmd = m.filterInput.Update(msg)
+	if m.filterInput.Value() != before {
+		m.rebuildRows()
+	}
+	return m, cmd
+}
+
+// Re-filters and re-sorts the table keeping the cursor on the same process when it still matches
+func (m *Model) rebuildRows() {
+	selectedPID := m.selectedPID()
+	m.updateTableRows(m.processSummaries)
+	m.selectPID(selectedPID)
+}
+
+func (m *Model) stopFiltering() {
+	m.filtering = false
+	m.mode = modeProcessList
+	m.modeColor = common.ColorModeNeutral
+	m.filterInput.Blur()
+	m.updateTableSize(m.width, m.height)
+}
+
+// the bar stays visible while a filter is applied so the list is not mistaken for the full one
+func (m Model) showFilterBar() bool {
+	return m.filtering || m.filterInput.Value() != ""
+}
+
 func maxFieldLengths(summaries []process.ProcessSummary) map[string]int {
 	maxLens := map[string]int{
+		"":        2,
 		"PID":     3, // set initial value to column header's length
 		"NAME":    4,
 		"SOCKS":   5,
@@ -255,21 +666,32 @@ func maxFieldLengths(summaries []process.ProcessSummary) map[string]int {
 	for _, p := range summaries {
 		maxLens["PID"] = max(maxLens["PID"], len(strconv.Itoa(p.PID)))
 		maxLens["NAME"] = max(maxLens["NAME"], len(p.Name))
-		maxLens["SOCKS"] = max(maxLens["SOCKS"], len(formatSocketText(p.LSocketCount, p.ESocketCount, p.CSocketCount)))
+		maxLens["SOCKS"] = max(maxLens["SOCKS"], len(formatSocketText(p.LSocketCount, p.ESocketCount, p.CSocketCount, p.USocketCount)))
 		maxLens["L.PORTS"] = max(maxLens["L.PORTS"], len(p.LPortsText))
 	}
 	return maxLens
 }
 
-func (m Model) getShowingProcessCount() int {
-	processCount := len(m.processSummaries)
-	tableHeight := m.table.Height()
-
-	showingProcessCount := min(processCount, tableHeight)
-	return showingProcessCount
+func (m *Model) mustSetCommandContext() {
+	err := m.setCurrentCommandContext()
+	if err != nil {
+		log.Fatalf("Process List Model Error: %v", err)
+	}
 }
 
 func (m *Model) setCurrentCommandContext() error {
+	switch m.mode {
+	case modeSendSignal:
+		return m.commandManager.SetContext(command.ContextSendSignal)
+	case modeConfirmSignal:
+		return m.commandManager.SetContext(command.ContextConfirmSignal)
+	}
+	if m.lookingUp {
+		return m.commandManager.SetContext(command.ContextPortLookup)
+	}
+	if m.filtering {
+		return m.commandManager.SetContext(command.ContextFilter)
+	}
 	err := m.commandManager.SetContext(command.ContextProcessListScreen)
 	return err
 }
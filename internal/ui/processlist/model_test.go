@@ -0,0 +1,100 @@
+package processlist
+
+import (
+	"netps/internal/ui/uitest"
+	"slices"
+	"strings"
+	"syscall"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// screen gives the list the tea.Model shape ui.Root gives it
+type screen struct {
+	Model
+	width, height int
+}
+
+func (s screen) Init() tea.Cmd {
+	return s.Model.Init(s.width, s.height)
+}
+
+func (s screen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	s.Model, cmd = s.Model.Update(msg)
+	return s, cmd
+}
+
+func start(t *testing.T, host *uitest.Host) *uitest.Driver {
+	t.Helper()
+	processService, socketService := host.Services()
+	m, err := New(uitest.Theme, uitest.Clock, uitest.Commands(t), processService, socketService)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uitest.Start(t, screen{Model: m, width: 100, height: 30}, 100, 30)
+}
+
+func TestSendSignal(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	d := start(t, host)
+
+	d.Press("down", "down", "s")
+	uitest.Golden(t, "signal_modal", d.Frame())
+
+	// SIGTERM is preselected and terminates, it needs a confirmation
+	d.Press("enter")
+	uitest.Golden(t, "signal_confirm", d.Frame())
+	if len(host.Delivered) != 0 {
+		t.Fatalf("delivered %v before the confirmation", host.Delivered)
+	}
+
+	d.Press("y")
+	if want := []uitest.Delivery{{PID: 812, Signal: syscall.SIGTERM}}; !slices.Equal(host.Delivered, want) {
+		t.Errorf("delivered %v, want %v", host.Delivered, want)
+	}
+	uitest.Golden(t, "signal_sent", d.Frame())
+
+	d.Press("delete")
+	if frame := d.Frame(); strings.Contains(frame, "SIGTERM sent") {
+		t.Errorf("notification still shown after dismissing it:\n%s", frame)
+	}
+}
+
+func TestCancelSignal(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	d := start(t, host)
+	list := d.Frame()
+
+	d.Press("s", "enter", "esc")
+	if frame := d.Frame(); !strings.Contains(frame, "Send Signal to Process") {
+		t.Errorf("esc on the confirmation should go back to the signal list:\n%s", frame)
+	}
+	d.Press("esc")
+	if got := d.Frame(); got != list {
+		t.Errorf("list after closing the modal:\n%s\nwant:\n%s", got, list)
+	}
+	if len(host.Delivered) != 0 {
+		t.Errorf("delivered %v after cancelling", host.Delivered)
+	}
+}
+
+func TestSendSignalToMarkedProcesses(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	host.Fail(uitest.SectionSignal, syscall.EPERM)
+	d := start(t, host)
+
+	d.Press("down", "m", "down", "down", "m", "s", "enter")
+	uitest.Golden(t, "signal_confirm_marked", d.Frame())
+
+	d.Press("y")
+	uitest.Golden(t, "signal_denied", d.Frame())
+}
+
+func TestFilter(t *testing.T) {
+	d := start(t, uitest.NewHost(uitest.Machine()))
+
+	d.Press("f", "n", "g", "enter")
+	uitest.Golden(t, "filtered", d.Frame())
+}
@@ -0,0 +1,121 @@
+package processlist
+
+import (
+	"netps/internal/process"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type sortKey int
+
+const (
+	sortByPID sortKey = iota
+	sortByName
+	sortByListenCount
+	sortByEstablishedCount
+	sortBySocketCount
+	sortByLowestListenPort
+	sortKeyCount
+)
+
+type sortOrder struct {
+	key        sortKey
+	descending bool
+}
+
+// Next sort key in the cycle, direction is kept
+func (o sortOrder) next() sortOrder {
+	o.key = (o.key + 1) % sortKeyCount
+	return o
+}
+
+func (o sortOrder) reversed() sortOrder {
+	o.descending = !o.descending
+	return o
+}
+
+// Column the indicator is drawn on, along with a hint when a column sorts by several keys
+func (o sortOrder) column() (title string, hint string) {
+	switch o.key {
+	case sortByName:
+		return "NAME", ""
+	case sortByListenCount:
+		return "SOCKS", "L"
+	case sortByEstablishedCount:
+		return "SOCKS", "E"
+	case sortBySocketCount:
+		return "SOCKS", "all"
+	case sortByLowestListenPort:
+		return "L.PORTS", ""
+	default:
+		return "PID", ""
+	}
+}
+
+func (o sortOrder) indicator() string {
+	_, hint := o.column()
+	arrow := "▲"
+	if o.descending {
+		arrow = "▼"
+	}
+	if hint != "" {
+		return " " + hint + arrow
+	}
+	return " " + arrow
+}
+
+// Sorts in place. Ties are broken by PID so rows do not jump around between refreshes
+func sortSummaries(summaries []process.ProcessSummary, order sortOrder) {
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+
+		// processes without a listening port go last in either direction
+		if order.key == sortByLowestListenPort {
+			aPort, aOK := lowestListenPort(a)
+			bPort, bOK := lowestListenPort(b)
+			if aOK != bOK {
+				return aOK
+			}
+			if aPort != bPort {
+				return (aPort < bPort) != order.descending
+			}
+			return a.PID < b.PID
+		}
+
+		c := compareSummaries(a, b, order.key)
+		if c == 0 {
+			return a.PID < b.PID
+		}
+		if order.descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+func compareSummaries(a, b process.ProcessSummary, key sortKey) int {
+	switch key {
+	case sortByName:
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	case sortByListenCount:
+		return a.LSocketCount - b.LSocketCount
+	case sortByEstablishedCount:
+		return a.ESocketCount - b.ESocketCount
+	case sortBySocketCount:
+		return socketCount(a) - socketCount(b)
+	default:
+		return a.PID - b.PID
+	}
+}
+
+func socketCount(p process.ProcessSummary) int {
+	return p.LSocketCount + p.ESocketCount + p.CSocketCount + p.USocketCount
+}
+
+func lowestListenPort(p process.ProcessSummary) (int, bool) {
+	if len(p.ListenPorts) == 0 {
+		return 0, false
+	}
+	return slices.Min(p.ListenPorts), true
+}
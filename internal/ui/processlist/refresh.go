@@ -0,0 +1,105 @@
+package processlist
+
+import (
+	"netps/internal/process"
+	"sort"
+)
+
+// number of refresh ticks a new or vanished process stays highlighted
+const highlightTicks = 3
+
+type rowChange int
+
+const (
+	rowUnchanged rowChange = iota
+	rowAppeared
+	rowVanished
+)
+
+type rowHighlight struct {
+	change    rowChange
+	ticksLeft int
+}
+
+// Merges a fresh hydration into the displayed list. Vanished processes are
+// kept (marked) until their highlight runs out so the eye can catch them
+func (m *Model) mergeSummaries(fresh []process.ProcessSummary) []process.ProcessSummary {
+	if m.highlights == nil {
+		m.highlights = map[int]rowHighlight{}
+	}
+
+	freshPIDs := map[int]bool{}
+	for _, p := range fresh {
+		freshPIDs[p.PID] = true
+	}
+
+	// the first hydration has nothing to compare against
+	if m.hydrated {
+		displayed := map[int]bool{}
+		for _, p := range m.processSummaries {
+			displayed[p.PID] = true
+		}
+		for _, p := range fresh {
+			h, known := m.highlights[p.PID]
+			if !displayed[p.PID] || (known && h.change == rowVanished) {
+				m.highlights[p.PID] = rowHighlight{change: rowAppeared, ticksLeft: highlightTicks}
+			}
+		}
+	}
+
+	merged := append([]process.ProcessSummary{}, fresh...)
+	for _, p := range m.processSummaries {
+		if freshPIDs[p.PID] {
+			continue
+		}
+		h, known := m.highlights[p.PID]
+		if !known || h.change != rowVanished {
+			h = rowHighlight{change: rowVanished, ticksLeft: highlightTicks}
+			m.highlights[p.PID] = h
+		}
+		if h.ticksLeft > 0 {
+			merged = append(merged, p)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].PID < merged[j].PID
+	})
+	return merged
+}
+
+// Ages every highlight by one tick, vanished rows whose highlight ran out are dropped
+func (m *Model) ageHighlights() {
+	for pid, h := range m.highlights {
+		h.ticksLeft--
+		if h.ticksLeft > 0 {
+			m.highlights[pid] = h
+			continue
+		}
+		delete(m.highlights, pid)
+		if h.change == rowVanished {
+			m.processSummaries = removeSummary(m.processSummaries, pid)
+		}
+	}
+}
+
+func removeSummary(summaries []process.ProcessSummary, pid int) []process.ProcessSummary {
+	out := make([]process.ProcessSummary, 0, len(summaries))
+	for _, p := range summaries {
+		if p.PID != pid {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func (m Model) changeMarker(pid int) string {
+	switch m.highlights[pid].change {
+	case rowAppeared:
+		return "+"
+	case rowVanished:
+		return "-"
+	default:
+		return ""
+	}
+}
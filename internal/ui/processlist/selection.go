@@ -0,0 +1,91 @@
+package processlist
+
+import (
+	"netps/internal/process"
+	"netps/internal/ui/message"
+)
+
+const selectedMarker = "●"
+
+func (m *Model) toggleSelection(pid int) {
+	if pid < 0 {
+		return
+	}
+	if m.selected == nil {
+		m.selected = map[int]bool{}
+	}
+	if m.selected[pid] {
+		delete(m.selected, pid)
+		return
+	}
+	m.selected[pid] = true
+}
+
+// Selected processes in display order, the cursor row when nothing is selected.
+// A vanished process stays a target until its row is dropped
+func (m Model) actionTargets() []message.ProcessRef {
+	targets := []message.ProcessRef{}
+	if len(m.selected) == 0 {
+		cursor := m.table.Cursor()
+		if cursor >= 0 && cursor < len(m.visibleSummaries) {
+			p := m.visibleSummaries[cursor]
+			targets = append(targets, message.ProcessRef{PID: p.PID, Name: p.Name})
+		}
+		return targets
+	}
+
+	for _, p := range m.visibleSummaries {
+		if m.selected[p.PID] {
+			targets = append(targets, message.ProcessRef{PID: p.PID, Name: p.Name})
+		}
+	}
+	// selected rows hidden by the filter are still part of the selection
+	for _, p := range m.processSummaries {
+		if m.selected[p.PID] && !containsRef(targets, p.PID) {
+			targets = append(targets, message.ProcessRef{PID: p.PID, Name: p.Name})
+		}
+	}
+	return targets
+}
+
+func (m Model) selectedSummaries() []process.ProcessSummary {
+	out := []process.ProcessSummary{}
+	for _, t := range m.actionTargets() {
+		for _, p := range m.processSummaries {
+			if p.PID == t.PID {
+				out = append(out, p)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Drops selected PIDs that are no longer listed at all
+func (m *Model) pruneSelection() {
+	listed := map[int]bool{}
+	for _, p := range m.processSummaries {
+		listed[p.PID] = true
+	}
+	for pid := range m.selected {
+		if !listed[pid] {
+			delete(m.selected, pid)
+		}
+	}
+}
+
+func containsRef(refs []message.ProcessRef, pid int) bool {
+	for _, r := range refs {
+		if r.PID == pid {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) selectionMarker(pid int) string {
+	if m.selected[pid] {
+		return selectedMarker
+	}
+	return " "
+}
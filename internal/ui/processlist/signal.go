@@ -0,0 +1,169 @@
+package processlist
+
+import (
+	"context"
+	"fmt"
+	"netps/internal/process"
+	"netps/internal/ui/common"
+	"netps/internal/ui/common/command"
+	"netps/internal/ui/common/sendsignal"
+	"netps/internal/ui/message"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+type signalResult struct {
+	Target message.ProcessRef
+	Err    error
+}
+
+// Sends the signal to every target one after the other, a failure does not stop the rest
+func DeliverSignals(ctx context.Context, targets []message.ProcessRef, sig process.Signal, processService *process.Service) tea.Cmd {
+	return func() tea.Msg {
+		results := []signalResult{}
+		for _, t := range targets {
+			if ctx.Err() != nil {
+				results = append(results, signalResult{Target: t, Err: ctx.Err()})
+				continue
+			}
+			err := processService.SendSignal(ctx, t.PID, sig.Number)
+			results = append(results, signalResult{Target: t, Err: err})
+		}
+		return signalsDeliveredMsg{Signal: sig, Results: results}
+	}
+}
+
+// One line summing up a bulk delivery, failures are listed with their reason
+func signalsNotification(sig process.Signal, results []signalResult) common.Notification {
+	failed := []string{}
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%d): %s", r.Target.Name, r.Target.PID, process.DeliveryFailureReason(r.Err)))
+		}
+	}
+
+	sent := len(results) - len(failed)
+	switch {
+	case len(failed) == 0:
+		return common.Notification{
+			ColorMode: common.ColorModeSuccess,
+			Info:      fmt.Sprintf("%s sent to %d %s", sig.Name, sent, pluralProcess(sent)),
+		}
+	case sent == 0:
+		return common.Notification{
+			ColorMode: common.ColorModeDanger,
+			Info:      fmt.Sprintf("%s not sent: %s", sig.Name, strings.Join(failed, ", ")),
+		}
+	default:
+		return common.Notification{
+			ColorMode: common.ColorModeWarning,
+			Info:      fmt.Sprintf("%s sent to %d of %d processes, failed: %s", sig.Name, sent, len(results), strings.Join(failed, ", ")),
+		}
+	}
+}
+
+func pluralProcess(n int) string {
+	if n == 1 {
+		return "process"
+	}
+	return "processes"
+}
+
+// Key handling while the signal modal or its confirmation is open,
+// the table underneath does not receive any key
+func (m Model) updateSignalModal(msg tea.KeyMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if press, ok := msg.(tea.KeyPressMsg); ok && m.mode == modeSendSignal && common.CapturesKey(press) {
+		m.signalModal, cmd = m.signalModal.Update(msg)
+		return m, cmd
+	}
+
+	switch m.commandManager.GetCommand(command.ToKeyPress(msg.String())) {
+	case command.CommandMove:
+		if m.mode == modeSendSignal {
+			m.signalModal, cmd = m.signalModal.Update(msg)
+		}
+		return m, cmd
+	case command.CommandExecute:
+		sig, ok := m.signalModal.SelectedSignal()
+		if !ok {
+			return m, nil
+		}
+		if sig.Destructive {
+			m.pendingSignal = sig
+			m.mode = modeConfirmSignal
+			m.modeColor = common.ColorModeWarning
+			m.mustSetCommandContext()
+			return m, nil
+		}
+		return m.deliverPendingSignal(sig)
+	case command.CommandConfirm:
+		if m.mode != modeConfirmSignal {
+			return m, nil
+		}
+		return m.deliverPendingSignal(m.pendingSignal)
+	case command.CommandBack:
+		if m.mode == modeConfirmSignal {
+			m.mode = modeSendSignal
+			m.modeColor = common.ColorModeSpecial
+		} else {
+			m.closeSignalModal()
+		}
+		m.mustSetCommandContext()
+		return m, nil
+	case command.CommandQuit:
+		m.closeSignalModal()
+		m.mustSetCommandContext()
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m Model) deliverPendingSignal(sig process.Signal) (Model, tea.Cmd) {
+	targets := m.pendingTargets
+	m.signalModal.Remember(sig)
+	m.closeSignalModal()
+	m.mustSetCommandContext()
+	return m, DeliverSignals(m.ctx, targets, sig, m.processService)
+}
+
+func (m *Model) closeSignalModal() {
+	m.mode = modeProcessList
+	m.modeColor = common.ColorModeNeutral
+	m.pendingSignal = process.Signal{}
+	m.pendingTargets = nil
+}
+
+func (m Model) confirmModal() string {
+	if len(m.pendingTargets) == 1 {
+		t := m.pendingTargets[0]
+		return sendsignal.ConfirmModal(m.pendingSignal, t.PID, t.Name)
+	}
+	names := []string{}
+	for _, t := range m.pendingTargets {
+		names = append(names, fmt.Sprintf("%s (%d)", t.Name, t.PID))
+	}
+	return sendsignal.ConfirmBulkModal(m.pendingSignal, names)
+}
+
+// Centers a modal on top of the list
+func (m Model) modalLayer(modal string) *lipgloss.Layer {
+	return lipgloss.NewLayer(modal).
+		X((m.width / 2) - (lipgloss.Width(modal) / 2)).
+		Y((m.height / 2) - (lipgloss.Height(modal) / 2)).
+		Z(1)
+}
+
+func (m Model) helpItems() []string {
+	switch m.mode {
+	case modeSendSignal:
+		return m.signalModal.SendSignalHelpItems
+	case modeConfirmSignal:
+		return m.signalModal.ConfirmHelpItems
+	default:
+		return m.commandManager.GenerateContextHelp()
+	}
+}
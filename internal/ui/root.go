@@ -2,11 +2,16 @@ package ui
 
 import (
 	"log"
+	"netps/internal/process"
+	"netps/internal/socket"
 	"netps/internal/ui/common"
 	"netps/internal/ui/common/command"
+	"netps/internal/ui/common/sendsignal"
 	"netps/internal/ui/message"
 	"netps/internal/ui/processdetail"
 	"netps/internal/ui/processlist"
+	"netps/internal/ui/socketview"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 )
@@ -16,6 +21,7 @@ type Screen int
 const (
 	ScreenProcessList Screen = iota
 	ScreenProcessDetail
+	ScreenSocketView
 )
 
 type Root struct {
@@ -25,9 +31,16 @@ type Root struct {
 	width, height  int
 	processList    processlist.Model
 	processDetail  processdetail.Model
+	socketView     socketview.Model
 }
 
-func New() (Root, error) {
+type Config struct {
+	RefreshInterval time.Duration // process list and detail auto-refresh, zero disables it
+	SnapshotAt      time.Time     // capture time when replaying a snapshot, zero when live
+	Clock           common.Clock  // schedules the screens' timers, common.WallClock when nil
+}
+
+func New(processService *process.Service, socketService *socket.Service, cfg Config) (Root, error) {
 	theme := common.Theme{
 		ColorForegroundBase:      common.ColorWhite,
 		ColorForegroundSubtle:    common.ColorDarkGray,
@@ -46,6 +59,11 @@ func New() (Root, error) {
 		SpacingMedium: 2,
 	}
 
+	clock := cfg.Clock
+	if clock == nil {
+		clock = common.WallClock{}
+	}
+
 	manager := command.NewManager()
 	err := manager.RegisterGlobalCommand(command.KeyQ, command.CommandQuit)
 	if err != nil {
@@ -59,16 +77,28 @@ func New() (Root, error) {
 	if err != nil {
 		return Root{}, err
 	}
+	// the signal modal is shared by the list and the detail screen
+	err = sendsignal.RegisterCommands(&manager)
+	if err != nil {
+		return Root{}, err
+	}
 
-	processlist, err := processlist.New(theme, &manager)
+	processlist, err := processlist.New(theme, clock, &manager, processService, socketService)
 	if err != nil {
 		log.Fatalf("Root error at New creating processlist: %v", err)
 	}
+	processlist = processlist.WithRefreshInterval(cfg.RefreshInterval).WithSnapshot(cfg.SnapshotAt)
 
-	processdetail, err := processdetail.New(theme, &manager)
+	processdetail, err := processdetail.New(theme, clock, &manager, processService, socketService)
 	if err != nil {
 		log.Fatalf("Root error at New creating processdetail: %v", err)
 	}
+	processdetail = processdetail.WithRefreshInterval(cfg.RefreshInterval)
+
+	socketview, err := socketview.New(theme, &manager, socketService)
+	if err != nil {
+		log.Fatalf("Root error at New creating socketview: %v", err)
+	}
 
 	return Root{
 		theme:          theme,
@@ -76,6 +106,7 @@ func New() (Root, error) {
 		screen:         ScreenProcessList,
 		processList:    processlist,
 		processDetail:  processdetail,
+		socketView:     socketview,
 	}, nil
 }
 
@@ -93,6 +124,9 @@ func (m Root) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case message.GoToProcessDetail:
 		m.screen = ScreenProcessDetail
 		return m, m.processDetail.Init(msg.PID, msg.Name, m.width, m.height)
+	case message.GoToSocketView:
+		m.screen = ScreenSocketView
+		return m, m.socketView.Init(msg.Processes, m.width, m.height)
 	case message.GoBack:
 		m.screen = ScreenProcessList
 		return m, m.processList.Init(m.width, m.height)
@@ -111,6 +145,12 @@ func (m Root) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		pd, cmd := m.processDetail.Update(msg)
 		m.processDetail = pd
 		return m, cmd
+
+	case ScreenSocketView:
+		var cmd tea.Cmd
+		sv, cmd := m.socketView.Update(msg)
+		m.socketView = sv
+		return m, cmd
 	}
 
 	return m, nil
@@ -123,6 +163,9 @@ func (m Root) View() tea.View {
 
 	case ScreenProcessDetail:
 		return m.processDetail.View()
+
+	case ScreenSocketView:
+		return m.socketView.View()
 	}
 	return tea.View{}
 }
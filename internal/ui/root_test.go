@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"errors"
+	"netps/internal/ui/uitest"
+	"testing"
+)
+
+const width, height = 100, 30
+
+func start(t *testing.T, host *uitest.Host) *uitest.Driver {
+	t.Helper()
+	processService, socketService := host.Services()
+	root, err := New(processService, socketService, Config{Clock: uitest.Clock})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uitest.Start(t, root, width, height)
+}
+
+func TestProcessList(t *testing.T) {
+	d := start(t, uitest.NewHost(uitest.Machine()))
+	uitest.Golden(t, "list", d.Frame())
+}
+
+func TestInspectAndGoBack(t *testing.T) {
+	d := start(t, uitest.NewHost(uitest.Machine()))
+	list := d.Frame()
+
+	d.Press("down", "down", "enter")
+	if got := d.Model().(Root).screen; got != ScreenProcessDetail {
+		t.Fatalf("screen %d after enter, want the process detail", got)
+	}
+	uitest.Golden(t, "detail", d.Frame())
+
+	d.Press("esc")
+	if got := d.Model().(Root).screen; got != ScreenProcessList {
+		t.Fatalf("screen %d after esc, want the process list", got)
+	}
+	if got := d.Frame(); got != list {
+		t.Errorf("list after going back:\n%s\nwant it as it was before:\n%s", got, list)
+	}
+}
+
+func TestViewSockets(t *testing.T) {
+	d := start(t, uitest.NewHost(uitest.Machine()))
+
+	d.Press("down", "v")
+	if got := d.Model().(Root).screen; got != ScreenSocketView {
+		t.Fatalf("screen %d after v, want the socket view", got)
+	}
+	uitest.Golden(t, "sockets", d.Frame())
+}
+
+func TestQuit(t *testing.T) {
+	d := start(t, uitest.NewHost(uitest.Machine()))
+	d.Press("q")
+	if !d.Quit() {
+		t.Error("q did not quit")
+	}
+}
+
+// Without a first list there is nothing to show, the program exits
+func TestFirstHydrationFailureQuits(t *testing.T) {
+	host := uitest.NewHost(uitest.Machine())
+	host.Fail(uitest.SectionList, errors.New("open /proc: permission denied"))
+	d := start(t, host)
+	if !d.Quit() {
+		t.Errorf("still running after the first list failed:\n%s", d.Frame())
+	}
+}
@@ -0,0 +1,32 @@
+package socketview
+
+import (
+	"context"
+	"netps/internal/socket"
+	"netps/internal/ui/message"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func Initialize(seq int, processes []message.ProcessRef, w, h int) tea.Cmd {
+	return func() tea.Msg {
+		return initMsg{
+			seq:       seq,
+			processes: processes,
+			width:     w,
+			height:    h,
+		}
+	}
+}
+
+func HydrateSockets(ctx context.Context, seq int, pid int, socketService *socket.Service) tea.Cmd {
+	return func() tea.Msg {
+		sockets, err := socketService.GetSocketsByStates(ctx, pid, socketStates)
+		return socketsHydratedMsg{
+			seq:     seq,
+			PID:     pid,
+			Sockets: sockets,
+			Err:     err,
+		}
+	}
+}
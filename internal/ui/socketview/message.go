@@ -0,0 +1,19 @@
+package socketview
+
+import (
+	"netps/internal/socket"
+	"netps/internal/ui/message"
+)
+
+type initMsg struct {
+	seq           int
+	processes     []message.ProcessRef
+	width, height int
+}
+
+type socketsHydratedMsg struct {
+	seq     int
+	PID     int
+	Sockets []socket.Socket
+	Err     error
+}
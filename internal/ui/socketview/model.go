@@ -0,0 +1,215 @@
+// Combined socket view: the sockets of several processes, grouped per process.
+// Opened from a multi-selection in the process list, esc goes back to it.
+
+package socketview
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"netps/internal/socket"
+	"netps/internal/ui/common"
+	"netps/internal/ui/common/command"
+	"netps/internal/ui/message"
+	"slices"
+	"strconv"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+const modeName = "Sockets"
+
+var socketStates = []socket.SocketState{socket.StateListen, socket.StateEstablished, socket.StateClose, socket.StateUnconnected}
+
+type Model struct {
+	processes []message.ProcessRef
+	sockets   map[int][]socket.Socket
+	errs      map[int]error
+	seq       int // results of a previous visit are dropped
+
+	viewportModel viewport.Model
+	width, height int
+
+	theme          common.Theme
+	commandManager *command.Manager
+	socketService  *socket.Service
+}
+
+func New(theme common.Theme, commandManager *command.Manager, socketService *socket.Service) (Model, error) {
+	err := registerContextualCommands(commandManager)
+	if err != nil {
+		return Model{}, err
+	}
+
+	return Model{
+		theme:          theme,
+		commandManager: commandManager,
+		socketService:  socketService,
+	}, nil
+}
+
+func registerContextualCommands(commandManager *command.Manager) error {
+	err := commandManager.RegisterContextCommand(command.ContextSocketView, command.KeyUp, command.CommandScroll)
+	if err != nil {
+		return err
+	}
+	err = commandManager.RegisterContextCommand(command.ContextSocketView, command.KeyDown, command.CommandScroll)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m Model) Init(processes []message.ProcessRef, w, h int) tea.Cmd {
+	seq := m.seq + 1
+	hydrations := []tea.Cmd{}
+	for _, p := range processes {
+		hydrations = append(hydrations, HydrateSockets(context.Background(), seq, p.PID, m.socketService))
+	}
+	return tea.Sequence(
+		Initialize(seq, processes, w, h),
+		tea.Batch(hydrations...),
+	)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.adjustViewportSize()
+		m.viewportModel.SetContent(m.renderContent())
+	case initMsg:
+		m.seq = msg.seq
+		m.processes = msg.processes
+		m.sockets = map[int][]socket.Socket{}
+		m.errs = map[int]error{}
+		m.width = msg.width
+		m.height = msg.height
+		m.viewportModel = viewport.New()
+		m.adjustViewportSize()
+		m.viewportModel.SetContent(m.renderContent())
+	case socketsHydratedMsg:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.errs[msg.PID] = msg.Err
+		} else {
+			m.sockets[msg.PID] = msg.Sockets
+		}
+		m.viewportModel.SetContent(m.renderContent())
+	case tea.KeyMsg:
+		switch m.commandManager.GetCommand(command.ToKeyPress(msg.String())) {
+		case command.CommandBack:
+			return m, func() tea.Msg {
+				return message.GoBack{}
+			}
+		case command.CommandQuit:
+			return m, tea.Quit
+		}
+	}
+
+	err := m.commandManager.SetContext(command.ContextSocketView)
+	if err != nil {
+		log.Fatalf("Socket View Model Error: %v", err)
+	}
+
+	m.viewportModel, cmd = m.viewportModel.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() tea.View {
+	v := tea.NewView(m.viewportModel.View() + "\n" + m.statusBar() + "\n" + common.ActionBar(m.width, m.commandManager.GenerateContextHelp()))
+	v.AltScreen = true
+	return v
+}
+
+func (m *Model) adjustViewportSize() {
+	statusBarHeight := lipgloss.Height(m.statusBar())
+	actionBarHeight := lipgloss.Height(common.ActionBar(m.width, m.commandManager.GenerateContextHelp()))
+	m.viewportModel.SetWidth(m.width)
+	m.viewportModel.SetHeight(max(1, m.height-statusBarHeight-actionBarHeight))
+}
+
+func (m Model) statusBar() string {
+	total := 0
+	for _, socks := range m.sockets {
+		total += len(socks)
+	}
+	info := fmt.Sprintf("%d sockets of %d processes", total, len(m.processes))
+
+	switch {
+	case len(m.sockets)+len(m.errs) < len(m.processes):
+		return common.StatusBar(m.theme, m.width, modeName, common.ColorModeSpecial, info, "Getting Data...", common.ColorModeNeutral)
+	case len(m.errs) > 0:
+		return common.StatusBar(m.theme, m.width, modeName, common.ColorModeSpecial, info, "Data Partial", common.ColorModeWarning)
+	default:
+		return common.StatusBar(m.theme, m.width, modeName, common.ColorModeSpecial, info, "Data OK", common.ColorModeSuccess)
+	}
+}
+
+func (m Model) renderContent() string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.theme.ColorForegroundBase)).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderBottom(true).
+		BorderForeground(lipgloss.Color(m.theme.ColorInactive)).
+		MarginTop(m.theme.SpacingSmall)
+	itemStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorForegroundBase))
+	subtleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.ColorForegroundSubtle))
+
+	selectedPIDs := []int{}
+	for _, p := range m.processes {
+		selectedPIDs = append(selectedPIDs, p.PID)
+	}
+
+	sections := []string{}
+	for _, p := range m.processes {
+		socks, hydrated := m.sockets[p.PID]
+		err := m.errs[p.PID]
+
+		header := fmt.Sprintf("%s (%d)", p.Name, p.PID)
+		if hydrated {
+			aggregated := socket.Aggregate(socks)
+			header += fmt.Sprintf(" · %dL %dE %dC %dU", aggregated.ListenCount, aggregated.EstablishedCount, aggregated.CloseCount, aggregated.UnixCount)
+		}
+
+		lines := []string{headerStyle.Render(header)}
+		switch {
+		case err != nil:
+			lines = append(lines, subtleStyle.Render("error: "+err.Error()))
+		case !hydrated:
+			lines = append(lines, subtleStyle.Render("getting sockets..."))
+		case len(socks) == 0:
+			lines = append(lines, subtleStyle.Render("no sockets"))
+		}
+		for _, s := range socks {
+			lines = append(lines, itemStyle.Render(formatSocketText(s, p.PID, selectedPIDs)))
+		}
+		sections = append(sections, lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// Sockets shared with another process of the view are called out, that overlap is
+// usually why several processes are looked at together
+func formatSocketText(sock socket.Socket, pid int, viewed []int) string {
+	text := fmt.Sprintf("• %s %s (%s)", sock.ProtoLabel(), sock.Endpoints(), sock.State)
+
+	shared := []string{}
+	for _, other := range sock.OtherOwnerPIDs(pid) {
+		if slices.Contains(viewed, other) {
+			shared = append(shared, strconv.Itoa(other))
+		}
+	}
+	if len(shared) > 0 {
+		text += " · also held by " + strings.Join(shared, ", ")
+	}
+	return text
+}
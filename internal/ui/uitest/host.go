@@ -0,0 +1,204 @@
+package uitest
+
+import (
+	"context"
+	"netps/internal/process"
+	"netps/internal/snapshot"
+	"netps/internal/socket"
+	"syscall"
+)
+
+// A section of the host a test can make fail, one per port the screens hydrate from
+type Section string
+
+const (
+	SectionList     Section = "list"
+	SectionDetail   Section = "detail"
+	SectionResource Section = "resource"
+	SectionUser     Section = "user"
+	SectionSockets  Section = "sockets"
+	SectionSignal   Section = "signal"
+)
+
+// What the screens asked the host to deliver
+type Delivery struct {
+	PID    int
+	Signal syscall.Signal
+}
+
+// Host serves a fixed machine through the snapshot adapter, with failures the
+// test switches on and off between key presses and a record of signals instead
+// of sending them
+type Host struct {
+	*snapshot.Source
+	failures  map[Section]error
+	Delivered []Delivery
+}
+
+func NewHost(processes []snapshot.Process) *Host {
+	return &Host{
+		Source: snapshot.NewSource(snapshot.Snapshot{
+			Version:   snapshot.Version,
+			ClockTick: 100,
+			PageSize:  4096,
+			UpTime:    86400,
+			Processes: processes,
+		}),
+		failures: map[Section]error{},
+	}
+}
+
+// Fail makes every later read of the section return err
+func (h *Host) Fail(section Section, err error) {
+	h.failures[section] = err
+}
+
+func (h *Host) Recover(section Section) {
+	delete(h.failures, section)
+}
+
+// Services wires the host the way cmd/netps wires the procfs client
+func (h *Host) Services() (*process.Service, *socket.Service) {
+	processService := process.NewProcessService(process.Config{
+		Process:   h,
+		Name:      h,
+		Detail:    h,
+		Clocktick: h,
+		PageSize:  h,
+		UpTime:    h,
+		Resource:  h,
+		User:      h,
+		Signal:    h,
+	})
+	return processService, socket.NewService(h)
+}
+
+func (h *Host) ListRunnings(ctx context.Context) ([]process.ProcessSummary, error) {
+	if err := h.failures[SectionList]; err != nil {
+		return nil, err
+	}
+	return h.Source.ListRunnings(ctx)
+}
+
+func (h *Host) Detail(ctx context.Context, pid int) (process.ProcessDetail, error) {
+	if err := h.failures[SectionDetail]; err != nil {
+		return process.ProcessDetail{}, err
+	}
+	return h.Source.Detail(ctx, pid)
+}
+
+func (h *Host) Resource(ctx context.Context, pid int) (process.ProcessResource, error) {
+	if err := h.failures[SectionResource]; err != nil {
+		return process.ProcessResource{}, err
+	}
+	return h.Source.Resource(ctx, pid)
+}
+
+func (h *Host) User(ctx context.Context, pid int) (process.ProcessUser, error) {
+	if err := h.failures[SectionUser]; err != nil {
+		return process.ProcessUser{}, err
+	}
+	return h.Source.User(ctx, pid)
+}
+
+func (h *Host) SocketsByStates(ctx context.Context, pid int, states []socket.SocketState) ([]socket.Socket, error) {
+	if err := h.failures[SectionSockets]; err != nil {
+		return []socket.Socket{}, err
+	}
+	return h.Source.SocketsByStates(ctx, pid, states)
+}
+
+func (h *Host) RunningSockets(ctx context.Context) (map[int][]socket.Socket, error) {
+	if err := h.failures[SectionSockets]; err != nil {
+		return nil, err
+	}
+	return h.Source.RunningSockets(ctx)
+}
+
+// Signal records the delivery, a failing signal section is what the kernel answered
+func (h *Host) Signal(ctx context.Context, pid int, sig syscall.Signal) error {
+	if err := h.failures[SectionSignal]; err != nil {
+		return err
+	}
+	h.Delivered = append(h.Delivered, Delivery{PID: pid, Signal: sig})
+	return nil
+}
+
+// Machine is the host most tests run on: systemd, sshd with a client connected,
+// nginx on 80 and 443 and dnsmasq answering on udp 53
+func Machine() []snapshot.Process {
+	return []snapshot.Process{
+		machineProcess(1, "systemd", "/usr/lib/systemd/systemd", "/sbin/init splash", 0, "", root,
+			unixSocket(1, 3, 12001, "/run/systemd/private", socket.StateListen),
+			unixSocket(1, 4, 12002, "", socket.StateEstablished),
+		),
+		machineProcess(700, "sshd", "/usr/sbin/sshd", "sshd: /usr/sbin/sshd -D [listener]", 1, "systemd", root,
+			inetSocket(700, 3, 13001, socket.ProtoTCP, "0.0.0.0", 22, "0.0.0.0", 0, socket.StateListen),
+			inetSocket(700, 4, 13002, socket.ProtoTCP6, "::", 22, "::", 0, socket.StateListen),
+			inetSocket(700, 5, 13003, socket.ProtoTCP, "10.0.0.5", 22, "10.0.0.9", 51514, socket.StateEstablished),
+		),
+		machineProcess(812, "nginx", "/usr/sbin/nginx", "nginx: master process /usr/sbin/nginx", 1, "systemd", wwwData,
+			inetSocket(812, 6, 14001, socket.ProtoTCP, "0.0.0.0", 80, "0.0.0.0", 0, socket.StateListen),
+			inetSocket(812, 7, 14002, socket.ProtoTCP, "0.0.0.0", 443, "0.0.0.0", 0, socket.StateListen),
+		),
+		machineProcess(930, "dnsmasq", "/usr/sbin/dnsmasq", "/usr/sbin/dnsmasq --keep-in-foreground", 1, "systemd", nobody,
+			inetSocket(930, 4, 15001, socket.ProtoUDP, "127.0.0.1", 53, "0.0.0.0", 0, socket.StateClose),
+		),
+	}
+}
+
+var (
+	root    = process.ProcessUser{RealUID: 0, Name: "root", Privileged: true}
+	wwwData = process.ProcessUser{RealUID: 33, Name: "www-data"}
+	nobody  = process.ProcessUser{RealUID: 65534, Name: "nobody"}
+)
+
+// Every process started a minute after boot and has used a few seconds of cpu
+func machineProcess(pid int, name, exe, cmdline string, ppid int, parent string, user process.ProcessUser, sockets ...socket.Socket) snapshot.Process {
+	return snapshot.Process{
+		PID:  pid,
+		Name: name,
+		Detail: &process.ProcessDetail{
+			Name:       name,
+			ExecPath:   exe,
+			Command:    cmdline,
+			PPID:       ppid,
+			ParentName: parent,
+		},
+		Resource: &process.ProcessResource{
+			ResidentSetSizePage:    2048,
+			VirtualMemorySize:      64 << 20,
+			StartTimeTick:          6000,
+			UserCPUTimeClockTick:   250,
+			SystemCPUTimeClockTick: 120,
+			State:                  "Sleeping",
+		},
+		User:    &user,
+		Sockets: sockets,
+	}
+}
+
+func inetSocket(pid, fd int, inode uint64, proto, addr string, port int, remoteAddr string, remotePort int, state socket.SocketState) socket.Socket {
+	return socket.Socket{
+		Proto:      proto,
+		Addr:       addr,
+		Port:       port,
+		RemoteAddr: remoteAddr,
+		RemotePort: remotePort,
+		State:      state,
+		Inode:      inode,
+		Owners:     []socket.Owner{{PID: pid, FD: fd}},
+	}
+}
+
+func unixSocket(pid, fd int, inode uint64, path string, state socket.SocketState) socket.Socket {
+	return socket.Socket{
+		Proto:  socket.ProtoUnix,
+		State:  state,
+		Path:   path,
+		Type:   "stream",
+		UID:    -1,
+		Inode:  inode,
+		Owners: []socket.Owner{{PID: pid, FD: fd}},
+	}
+}
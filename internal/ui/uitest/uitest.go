@@ -0,0 +1,218 @@
+// Package uitest drives the screens headlessly: a scripted session feeds window
+// sizes and key presses to a model, runs the commands it returns in place of the
+// Bubble Tea runtime and compares the rendered frames with golden files.
+//
+// The services are backed by a fake Host, see Machine for the processes it serves.
+// Run the tests with -update to rewrite the golden files after a deliberate change.
+package uitest
+
+import (
+	"flag"
+	"fmt"
+	"netps/internal/ui/common"
+	"netps/internal/ui/common/command"
+	"netps/internal/ui/common/sendsignal"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden frames under testdata/ with the current output")
+
+// Colors are stripped from the frames, only the spacing shows
+var Theme = common.Theme{
+	SpacingSmall:  1,
+	SpacingMedium: 2,
+}
+
+// Commands returns a manager with the global keys ui.New registers
+func Commands(t testing.TB) *command.Manager {
+	t.Helper()
+	manager := command.NewManager()
+	for key, c := range map[command.KeyPress]command.Command{
+		command.KeyQ:     command.CommandQuit,
+		command.KeyCtrlC: command.CommandQuit,
+		command.KeyEsc:   command.CommandBack,
+	} {
+		if err := manager.RegisterGlobalCommand(key, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sendsignal.RegisterCommands(&manager); err != nil {
+		t.Fatal(err)
+	}
+	return &manager
+}
+
+// Clock is the time of a scripted session, it does not pass: a tick hands the driver
+// a timerMsg it drops instead of waiting and text cursors do not blink.
+// Tests fire a tick by sending its message themselves
+var Clock common.Clock = clock{}
+
+type clock struct{}
+
+type timerMsg struct{}
+
+func (clock) Tick(d time.Duration, fn func(time.Time) tea.Msg) tea.Cmd {
+	return func() tea.Msg { return timerMsg{} }
+}
+
+func (clock) CursorBlink() bool {
+	return false
+}
+
+var cmdType = reflect.TypeFor[tea.Cmd]()
+
+// Driver plays the part of tea.Program, one message at a time
+type Driver struct {
+	t     testing.TB
+	model tea.Model
+	quit  bool
+}
+
+// Start sizes the window, as the terminal does first, then runs Init
+func Start(t testing.TB, model tea.Model, width, height int) *Driver {
+	t.Helper()
+	d := &Driver{t: t, model: model}
+	d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+	d.run(d.model.Init())
+	return d
+}
+
+// Send updates the model with msg and runs whatever it asks for, until nothing is left
+func (d *Driver) Send(msg tea.Msg) {
+	d.t.Helper()
+	if d.quit {
+		d.t.Fatalf("%T sent after the program quit", msg)
+	}
+	model, cmd := d.model.Update(msg)
+	d.model = model
+	d.run(cmd)
+}
+
+// Press sends each key in turn, named as tea.KeyPressMsg.String() names them
+func (d *Driver) Press(keys ...string) {
+	d.t.Helper()
+	for _, k := range keys {
+		d.Send(keyPress(d.t, k))
+	}
+}
+
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+func (d *Driver) Model() tea.Model {
+	return d.model
+}
+
+// Frame is the current view as the terminal would show it, without colors
+func (d *Driver) Frame() string {
+	d.t.Helper()
+	var out string
+	switch content := d.model.View().Content.(type) {
+	case interface{ Render() string }:
+		out = content.Render()
+	case fmt.Stringer:
+		out = content.String()
+	case nil:
+		return ""
+	default:
+		d.t.Fatalf("cannot render view content %T", content)
+	}
+	return normalize(out)
+}
+
+func (d *Driver) run(cmd tea.Cmd) {
+	d.t.Helper()
+	if cmd == nil || d.quit {
+		return
+	}
+	// every timer goes through Clock and the fake host answers at once, so a command
+	// blocking here is a timer scheduled behind the clock's back
+	msg := cmd()
+	if msg == nil {
+		return
+	}
+	if _, ok := msg.(timerMsg); ok {
+		return
+	}
+	if _, ok := msg.(tea.QuitMsg); ok {
+		d.quit = true
+		return
+	}
+	// tea.Batch and tea.Sequence both hand back their commands as a []tea.Cmd, the
+	// sequence under an unexported type. Running a batch in order is one of its valid schedules
+	if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().Elem() == cmdType {
+		for i := range v.Len() {
+			d.run(v.Index(i).Interface().(tea.Cmd))
+		}
+		return
+	}
+	d.Send(msg)
+}
+
+var specialKeys = map[string]rune{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEscape,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"delete":    tea.KeyDelete,
+	"backspace": tea.KeyBackspace,
+}
+
+func keyPress(t testing.TB, name string) tea.KeyPressMsg {
+	t.Helper()
+	if code, ok := specialKeys[name]; ok {
+		return tea.KeyPressMsg{Code: code}
+	}
+	if name == "ctrl+c" {
+		return tea.KeyPressMsg{Code: 'c', Mod: tea.ModCtrl}
+	}
+	if r := []rune(name); len(r) == 1 {
+		return tea.KeyPressMsg{Code: r[0], Text: name}
+	}
+	t.Fatalf("unknown key %q", name)
+	return tea.KeyPressMsg{}
+}
+
+// CSI and OSC sequences, plus the two-byte escapes lipgloss does not emit but a terminal would accept
+var escapes = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// Drops the colors and the padding a canvas adds to the right of every line
+func normalize(frame string) string {
+	lines := strings.Split(escapes.ReplaceAllString(frame, ""), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// Golden compares frame with testdata/<name>.golden of the calling package
+func Golden(t testing.TB, name string, frame string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(frame), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v, run the tests with -update to create it", err)
+	}
+	if string(want) != frame {
+		t.Errorf("%s differs from %s, run the tests with -update if the change is intended\n got:\n%s\nwant:\n%s", name, path, frame, want)
+	}
+}
@@ -0,0 +1,234 @@
+//go:build linux
+
+package regression
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// The test binary doubles as the helper: started with scenarioEnv set it opens
+// the sockets of that scenario, reports them on stdout and holds them until stdin closes
+const (
+	scenarioEnv = "NETPS_REGRESSION_SCENARIO"
+	dirEnv      = "NETPS_REGRESSION_DIR"
+
+	// a forked worker finds the inherited listener right after stdin, stdout and stderr
+	inheritedFD = 3
+)
+
+type scenario func(dir string) (layout, error)
+
+var scenarios = map[string]scenario{
+	"multi-port":     multiPort,
+	"shared-listen":  sharedListener,
+	"worker":         worker,
+	"udp":            udp,
+	"unix":           unixSockets,
+	"ipv6-only":      ipv6Only,
+	"tcp-connection": tcpConnection,
+}
+
+// held keeps what a scenario opened reachable: a net.Listener, net.Conn or *os.File
+// that became garbage would have its fd closed by a finalizer while the test reads /proc
+var held []any
+
+func hold(v ...any) {
+	held = append(held, v...)
+}
+
+// What a helper opened, as it reports it to the test
+type layout struct {
+	PID     int      `json:"pid"`
+	Ports   []int    `json:"ports,omitempty"`
+	Paths   []string `json:"paths,omitempty"`
+	Workers []int    `json:"workers,omitempty"`
+}
+
+func TestMain(m *testing.M) {
+	if name := os.Getenv(scenarioEnv); name != "" {
+		os.Exit(runHelper(name))
+	}
+	os.Exit(m.Run())
+}
+
+func runHelper(name string) int {
+	open, ok := scenarios[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown scenario %q\n", name)
+		return 1
+	}
+	l, err := open(os.Getenv(dirEnv))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scenario %s: %v\n", name, err)
+		return 1
+	}
+	l.PID = os.Getpid()
+	if err := json.NewEncoder(os.Stdout).Encode(l); err != nil {
+		return 1
+	}
+	// every socket stays open until the test is done with the scenario
+	io.Copy(io.Discard, os.Stdin)
+	runtime.KeepAlive(held)
+	return 0
+}
+
+// spawn starts a helper for the scenario and waits until its sockets are open
+func spawn(t *testing.T, name string) layout {
+	t.Helper()
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(), scenarioEnv+"="+name, dirEnv+"="+t.TempDir())
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		stdin.Close()
+		cmd.Wait()
+	})
+
+	ready := make(chan error, 1)
+	var l layout
+	go func() {
+		ready <- json.NewDecoder(stdout).Decode(&l)
+	}()
+	select {
+	case err := <-ready:
+		if err != nil {
+			t.Fatalf("scenario %s did not start: %v", name, err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("scenario %s did not start in time", name)
+	}
+	return l
+}
+
+// Several listeners on one pid, what the Python script used to set up by hand
+func multiPort(string) (layout, error) {
+	l := layout{}
+	for range 3 {
+		ln, err := net.Listen("tcp4", "127.0.0.1:0")
+		if err != nil {
+			return l, err
+		}
+		hold(ln)
+		l.Ports = append(l.Ports, ln.Addr().(*net.TCPAddr).Port)
+	}
+	return l, nil
+}
+
+// A prefork server: one listener inherited by two workers
+func sharedListener(string) (layout, error) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		return layout{}, err
+	}
+	f, err := ln.(*net.TCPListener).File()
+	if err != nil {
+		return layout{}, err
+	}
+	hold(ln, f)
+	l := layout{Ports: []int{ln.Addr().(*net.TCPAddr).Port}}
+	for range 2 {
+		cmd := exec.Command(os.Args[0])
+		cmd.Env = append(os.Environ(), scenarioEnv+"=worker")
+		cmd.Stdin = os.Stdin // the workers exit along with the parent when the test closes it
+		cmd.Stderr = os.Stderr
+		cmd.ExtraFiles = []*os.File{f}
+		out, err := cmd.StdoutPipe()
+		if err != nil {
+			return l, err
+		}
+		if err := cmd.Start(); err != nil {
+			return l, err
+		}
+		hold(cmd)
+		var w layout
+		if err := json.NewDecoder(bufio.NewReader(out)).Decode(&w); err != nil {
+			return l, fmt.Errorf("worker: %w", err)
+		}
+		l.Workers = append(l.Workers, w.PID)
+	}
+	return l, nil
+}
+
+func worker(string) (layout, error) {
+	f := os.NewFile(inheritedFD, "listener")
+	ln, err := net.FileListener(f)
+	if err != nil {
+		return layout{}, err
+	}
+	hold(f, ln)
+	return layout{Ports: []int{ln.Addr().(*net.TCPAddr).Port}}, nil
+}
+
+func udp(string) (layout, error) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	if err != nil {
+		return layout{}, err
+	}
+	hold(conn)
+	return layout{Ports: []int{conn.LocalAddr().(*net.UDPAddr).Port}}, nil
+}
+
+// A named listener and a connected socketpair
+func unixSockets(dir string) (layout, error) {
+	path := filepath.Join(dir, "helper.sock")
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return layout{}, err
+	}
+	hold(ln)
+	// raw fds have no finalizer, they stay open until the helper exits
+	if _, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0); err != nil {
+		return layout{}, err
+	}
+	return layout{Paths: []string{path}}, nil
+}
+
+func ipv6Only(string) (layout, error) {
+	ln, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		return layout{}, err
+	}
+	hold(ln)
+	return layout{Ports: []int{ln.Addr().(*net.TCPAddr).Port}}, nil
+}
+
+// A listener with one accepted connection, both ends held by the same pid
+func tcpConnection(string) (layout, error) {
+	ln, err := net.Listen("tcp4", "127.0.0.1:0")
+	if err != nil {
+		return layout{}, err
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	client, err := net.Dial("tcp4", "127.0.0.1:"+strconv.Itoa(port))
+	if err != nil {
+		return layout{}, err
+	}
+	server, err := ln.Accept()
+	if err != nil {
+		return layout{}, err
+	}
+	hold(ln, client, server)
+	return layout{Ports: []int{port, client.LocalAddr().(*net.TCPAddr).Port}}, nil
+}
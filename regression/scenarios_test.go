@@ -0,0 +1,218 @@
+//go:build linux
+
+package regression
+
+import (
+	"context"
+	"net"
+	"netps/internal/netlink"
+	"netps/internal/process"
+	"netps/internal/procfs"
+	procnet "netps/internal/procfs/net"
+	"netps/internal/procfs/root"
+	"netps/internal/socket"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// forEachTable runs the assertions against the /proc/net parser and, when the
+// host allows it, sock_diag: both must describe the same layout
+func forEachTable(t *testing.T, check func(t *testing.T, client *procfs.Client)) {
+	fsys := root.Default()
+	if _, err := fsys.ReadFile("net/tcp"); err != nil {
+		t.Skipf("no proc mount: %v", err)
+	}
+	tables := map[string]procnet.SocketTable{"procfs": procnet.ProcTable{FS: fsys}}
+	if netlink.Available() {
+		tables["netlink"] = netlink.NewTable(fsys)
+	}
+	for name, table := range tables {
+		t.Run(name, func(t *testing.T) {
+			check(t, procfs.NewClient(fsys).WithSocketTable(table))
+		})
+	}
+}
+
+func summaryOf(t *testing.T, client *procfs.Client, pid int) process.ProcessSummary {
+	t.Helper()
+	summaries, err := client.ListRunnings(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range summaries {
+		if s.PID == pid {
+			return s
+		}
+	}
+	t.Fatalf("pid %d is not listed", pid)
+	return process.ProcessSummary{}
+}
+
+// Only the counts, ports and protocols are asserted, name and display text come from the host
+func assertSummary(t *testing.T, got process.ProcessSummary, want process.ProcessSummary) {
+	t.Helper()
+	want.PID, want.Name, want.LPortsText = got.PID, got.Name, got.LPortsText
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("summary of %d\n got: %+v\nwant: %+v", got.PID, got, want)
+	}
+}
+
+func socketsOf(t *testing.T, client *procfs.Client, pid int, states ...socket.SocketState) []socket.Socket {
+	t.Helper()
+	socks, err := client.SocketsByStates(context.Background(), pid, states)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return socks
+}
+
+func ports(socks []socket.Socket) []int {
+	out := []int{}
+	for _, s := range socks {
+		out = append(out, s.Port)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func TestMultiplePortsSamePID(t *testing.T) {
+	l := spawn(t, "multi-port")
+	slices.Sort(l.Ports)
+	forEachTable(t, func(t *testing.T, client *procfs.Client) {
+		assertSummary(t, summaryOf(t, client, l.PID), process.ProcessSummary{
+			LSocketCount: 3,
+			ListenPorts:  l.Ports,
+			Protos:       []string{socket.ProtoTCP},
+		})
+
+		socks := socketsOf(t, client, l.PID, socket.StateListen)
+		if got := ports(socks); !slices.Equal(got, l.Ports) {
+			t.Errorf("listening ports %v, want %v", got, l.Ports)
+		}
+		for _, s := range socks {
+			if s.Addr != "127.0.0.1" || s.IsShared() {
+				t.Errorf("socket %+v, want 127.0.0.1 held by %d alone", s, l.PID)
+			}
+		}
+	})
+}
+
+func TestForkedWorkersShareListener(t *testing.T) {
+	l := spawn(t, "shared-listen")
+	holders := append([]int{l.PID}, l.Workers...)
+	slices.Sort(holders)
+	forEachTable(t, func(t *testing.T, client *procfs.Client) {
+		for _, pid := range holders {
+			assertSummary(t, summaryOf(t, client, pid), process.ProcessSummary{
+				LSocketCount: 1,
+				SharedCount:  1,
+				ListenPorts:  l.Ports,
+				Protos:       []string{socket.ProtoTCP},
+			})
+
+			socks := socketsOf(t, client, pid, socket.StateListen)
+			if len(socks) != 1 {
+				t.Fatalf("pid %d: %d listening sockets, want the inherited one", pid, len(socks))
+			}
+			// the queried pid comes first, then every other holder
+			owners := socks[0].OwnerPIDs()
+			if len(owners) == 0 || owners[0] != pid {
+				t.Errorf("pid %d: listener owned by %v, want %d first", pid, owners, pid)
+			}
+			slices.Sort(owners)
+			if !slices.Equal(owners, holders) {
+				t.Errorf("pid %d: listener owned by %v, want %v", pid, owners, holders)
+			}
+		}
+	})
+}
+
+func TestUDP(t *testing.T) {
+	l := spawn(t, "udp")
+	forEachTable(t, func(t *testing.T, client *procfs.Client) {
+		assertSummary(t, summaryOf(t, client, l.PID), process.ProcessSummary{
+			CSocketCount: 1,
+			ListenPorts:  []int{},
+			Protos:       []string{socket.ProtoUDP},
+		})
+
+		socks := socketsOf(t, client, l.PID, socket.StateClose)
+		if got := ports(socks); !slices.Equal(got, l.Ports) {
+			t.Fatalf("udp ports %v, want %v", got, l.Ports)
+		}
+		if !socks[0].IsBound() {
+			t.Errorf("bound udp socket %+v not reported as bound", socks[0])
+		}
+	})
+}
+
+func TestUnixSockets(t *testing.T) {
+	l := spawn(t, "unix")
+	forEachTable(t, func(t *testing.T, client *procfs.Client) {
+		assertSummary(t, summaryOf(t, client, l.PID), process.ProcessSummary{
+			USocketCount: 3,
+			ListenPorts:  []int{},
+			Protos:       []string{socket.ProtoUnix},
+		})
+
+		listening := socketsOf(t, client, l.PID, socket.StateListen)
+		if len(listening) != 1 || listening[0].Path != l.Paths[0] || listening[0].Type != "stream" {
+			t.Errorf("listening unix sockets %+v, want a stream socket at %s", listening, l.Paths[0])
+		}
+		pair := socketsOf(t, client, l.PID, socket.StateEstablished)
+		if len(pair) != 2 {
+			t.Fatalf("%d connected unix sockets, want both ends of the pair", len(pair))
+		}
+		for _, s := range pair {
+			if s.Path != "" {
+				t.Errorf("socketpair end %+v has a path", s)
+			}
+		}
+	})
+}
+
+func TestIPv6Only(t *testing.T) {
+	ln, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("no IPv6 loopback: %v", err)
+	}
+	ln.Close()
+
+	l := spawn(t, "ipv6-only")
+	forEachTable(t, func(t *testing.T, client *procfs.Client) {
+		assertSummary(t, summaryOf(t, client, l.PID), process.ProcessSummary{
+			LSocketCount: 1,
+			ListenPorts:  l.Ports,
+			Protos:       []string{socket.ProtoTCP6},
+		})
+
+		socks := socketsOf(t, client, l.PID, socket.StateListen)
+		if len(socks) != 1 || socks[0].Addr != "::1" {
+			t.Errorf("listening sockets %+v, want one on ::1", socks)
+		}
+	})
+}
+
+func TestEstablishedConnection(t *testing.T) {
+	l := spawn(t, "tcp-connection")
+	listenPort, clientPort := l.Ports[0], l.Ports[1]
+	forEachTable(t, func(t *testing.T, client *procfs.Client) {
+		assertSummary(t, summaryOf(t, client, l.PID), process.ProcessSummary{
+			LSocketCount: 1,
+			ESocketCount: 2,
+			ListenPorts:  []int{listenPort},
+			Protos:       []string{socket.ProtoTCP},
+		})
+
+		conns := socketsOf(t, client, l.PID, socket.StateEstablished)
+		if got, want := ports(conns), []int{listenPort, clientPort}; !slices.Equal(got, slices.Sorted(slices.Values(want))) {
+			t.Errorf("connection ports %v, want %v", got, want)
+		}
+		for _, c := range conns {
+			if c.Port == clientPort && c.RemotePort != listenPort {
+				t.Errorf("client end %+v, want it connected to %d", c, listenPort)
+			}
+		}
+	})
+}